@@ -1,20 +1,78 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"os"
+	"strconv"
 	"testdoubles/internal/application"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/scenario"
+	"time"
 )
 
 func main() {
+	// flags
+	// - scenario: YAML or JSON file with the species, the arena, the simulator and the subjects (its positioner replaces the env)
+	scenarioPath := flag.String("scenario", "", "path of a scenario file (YAML or JSON)")
+	flag.Parse()
+
 	// env
-	// ...
+	// - POSITIONER: metric of the distances (euclidean, manhattan, chebyshev, weighted or haversine)
+	// - POSITIONER_VERTICAL_COST: cost of the vertical distance of the weighted metric
+	cfgPositioner := positioner.ConfigPositioner{Metric: os.Getenv("POSITIONER")}
+	if v := os.Getenv("POSITIONER_VERTICAL_COST"); v != "" {
+		verticalCost, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		cfgPositioner.VerticalCost = verticalCost
+	}
+	// - SIMULATION_TTL: how long a simulation lives without requests, e.g. 10m (default: 30m)
+	var simulationTTL time.Duration
+	if v := os.Getenv("SIMULATION_TTL"); v != "" {
+		var err error
+		simulationTTL, err = time.ParseDuration(v)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+	// - MAX_SIMULATIONS: max number of live simulations (default: 1000)
+	var maxSimulations int
+	if v := os.Getenv("MAX_SIMULATIONS"); v != "" {
+		var err error
+		maxSimulations, err = strconv.Atoi(v)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+
+	// scenario
+	var sc *scenario.Scenario
+	if *scenarioPath != "" {
+		var err error
+		sc, err = scenario.LoadFile(*scenarioPath)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
 
 	// app
 	// - config
 	fmt.Println("http://localhost:8080")
-	app := application.NewApplicationDefault(":8080")
+	app := application.NewApplicationDefault(application.ConfigApplicationDefault{
+		Addr:           ":8080",
+		Positioner:     cfgPositioner,
+		Scenario:       sc,
+		SimulationTTL:  simulationTTL,
+		MaxSimulations: maxSimulations,
+	})
 	// - tear down
-	// defer app.TearDown()
+	defer app.TearDown()
 	// - set up
 	if err := app.SetUp(); err != nil {
 		fmt.Println(err)
@@ -1,43 +1,82 @@
 package application
 
 import (
+	"fmt"
 	"log"
 	"net/http"
 	"testdoubles/internal/handler"
 	"testdoubles/internal/hunter"
 	"testdoubles/internal/positioner"
 	"testdoubles/internal/prey"
+	"testdoubles/internal/scenario"
 	"testdoubles/internal/simulator"
+	"testdoubles/internal/species"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
+// ConfigApplicationDefault is the configuration for ApplicationDefault.
+type ConfigApplicationDefault struct {
+	// Addr is the address of the server (default: :8080)
+	Addr string
+	// Positioner picks the metric of the distances between hunters and preys (default: euclidean)
+	// - with the haversine metric the positions are geographic: latitude, longitude and depth
+	Positioner positioner.ConfigPositioner
+	// Scenario preloads the positioner, the arena, the simulator and the first hunter and prey (nil for the defaults)
+	// - its positioner takes the place of Positioner
+	// - all its hunters and preys are preloaded into /hunters and /prey, under their ids
+	Scenario *scenario.Scenario
+	// SimulationTTL is how long a simulation of /simulations lives without requests (default: 30 minutes)
+	SimulationTTL time.Duration
+	// MaxSimulations is the max number of live simulations of /simulations (default: 1000)
+	MaxSimulations int
+}
+
 // ApplicationDefault is the default implementation of Application interface.
 type ApplicationDefault struct {
 	// rt is the router of the server
 	rt *chi.Mux
 	// addr is the address of the server
 	addr string
+	// positioner is the configuration of the positioner
+	positioner positioner.ConfigPositioner
+	// scenario is the scenario to preload (nil for the defaults)
+	scenario *scenario.Scenario
+	// simulationTTL is how long a simulation lives without requests
+	simulationTTL time.Duration
+	// maxSimulations is the max number of live simulations
+	maxSimulations int
+	// sims are the simulations of the clients (nil until SetUp)
+	sims *handler.Simulations
 }
 
 // NewApplicationDefault creates a new ApplicationDefault instance.
-func NewApplicationDefault(addr string) *ApplicationDefault {
+func NewApplicationDefault(cfg ConfigApplicationDefault) *ApplicationDefault {
 	// default config
 	defaultRouter := chi.NewRouter()
 	defaultAddr := ":8080"
-	if addr != "" {
-		defaultAddr = addr
+	if cfg.Addr != "" {
+		defaultAddr = cfg.Addr
 	}
 
 	return &ApplicationDefault{
-		rt:   defaultRouter,
-		addr: defaultAddr,
+		rt:             defaultRouter,
+		addr:           defaultAddr,
+		positioner:     cfg.Positioner,
+		scenario:       cfg.Scenario,
+		simulationTTL:  cfg.SimulationTTL,
+		maxSimulations: cfg.MaxSimulations,
 	}
 }
 
 // TearDown tears down the application.
 func (a *ApplicationDefault) TearDown() (err error) {
+	// - stop sweeping the expired simulations
+	if a.sims != nil {
+		a.sims.StopSweeping()
+	}
 	return
 }
 
@@ -46,22 +85,38 @@ func (a *ApplicationDefault) SetUp() (err error) {
 	log.Println("call SetUp")
 
 	// dependencies
-	// - positioner
-	ps := positioner.NewPositionerDefault()
-	// - catch simulator
-	sm := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
-		Positioner: ps,
-	})
-	// - hunter
-	ht := hunter.NewWhiteShark(hunter.ConfigWhiteShark{
-		Speed:     0.0,
-		Position:  &positioner.Position{X: 0.0, Y: 0.0, Z: 0.0},
-		Simulator: sm,
-	})
-	// - prey
-	pr := prey.NewTuna(0.0, &positioner.Position{X: 0.0, Y: 0.0, Z: 0.0})
-	// - handler
-	hd := handler.NewHunter(ht, pr)
+	if a.scenario != nil {
+		log.Printf("scenario %q", a.scenario.Name)
+	}
+	// - the shared simulation of the /hunter routes
+	sim, err := a.newSimulation()
+	if err != nil {
+		return
+	}
+	// - resources: hunters, preys and the hunts between them (the hunts do not keep the trajectories)
+	arResources, _, smResources, err := a.simulators()
+	if err != nil {
+		return
+	}
+	var catalogue species.Catalogue
+	if a.scenario != nil {
+		catalogue = a.scenario.Catalogue()
+	}
+	hunters := handler.NewHunters(smResources, arResources, catalogue)
+	preys := handler.NewPreys(arResources, catalogue)
+	hunts := handler.NewHunts(hunters, preys)
+	// - the hunters and the preys of the scenario, all of them
+	if a.scenario != nil {
+		err = a.addSubjects(hunters, preys, smResources)
+		if err != nil {
+			return
+		}
+	}
+	// - simulations: each client creates its own, with its own hunter, prey, simulators and arena
+	// - the expired ones are swept on a ticker, until TearDown (it starts last, so a failed SetUp leaves nothing running)
+	sims := handler.NewSimulations(a.newSimulation, a.simulationTTL, a.maxSimulations)
+	sims.StartSweeping(0)
+	a.sims = sims
 
 	// router
 	// - middlewares
@@ -70,13 +125,162 @@ func (a *ApplicationDefault) SetUp() (err error) {
 	// - routes / endpoints
 	a.rt.Route("/hunter", func(r chi.Router) {
 		// POST /hunter/configure-prey
-		r.Post("/configure-prey", hd.ConfigurePrey)
+		r.Post("/configure-prey", sim.Hunter.ConfigurePrey)
 		// POST /hunter/configure-hunter
-		r.Post("/configure-hunter", hd.ConfigureHunter())
+		r.Post("/configure-hunter", sim.Hunter.ConfigureHunter())
 		// POST /hunter/hunt
-		r.Post("/hunt", hd.Hunt())
+		r.Post("/hunt", sim.Hunter.Hunt())
+		// POST /hunter/hunt/batch
+		r.Post("/hunt/batch", sim.Batch.Hunt())
+		// GET /hunter/trajectories/{id}
+		r.Get("/trajectories/{id}", sim.Hunter.Trajectory())
+	})
+	a.rt.Route("/hunters", func(r chi.Router) {
+		// GET /hunters
+		r.Get("/", hunters.List())
+		// POST /hunters
+		r.Post("/", hunters.Create())
+		// GET /hunters/{id}
+		r.Get("/{id}", hunters.Get())
+		// PUT /hunters/{id}
+		r.Put("/{id}", hunters.Replace())
+		// PATCH /hunters/{id}
+		r.Patch("/{id}", hunters.Update())
+		// DELETE /hunters/{id}
+		r.Delete("/{id}", hunters.Delete())
+	})
+	a.rt.Route("/prey", func(r chi.Router) {
+		// GET /prey
+		r.Get("/", preys.List())
+		// POST /prey
+		r.Post("/", preys.Create())
+		// GET /prey/{id}
+		r.Get("/{id}", preys.Get())
+		// PUT /prey/{id}
+		r.Put("/{id}", preys.Replace())
+		// PATCH /prey/{id}
+		r.Patch("/{id}", preys.Update())
+		// DELETE /prey/{id}
+		r.Delete("/{id}", preys.Delete())
+	})
+	a.rt.Route("/hunts", func(r chi.Router) {
+		// GET /hunts
+		r.Get("/", hunts.List())
+		// POST /hunts
+		r.Post("/", hunts.Create())
+		// GET /hunts/{id}
+		r.Get("/{id}", hunts.Get())
+	})
+	a.rt.Route("/simulations", func(r chi.Router) {
+		// POST /simulations
+		r.Post("/", sims.Create())
+		r.Route("/{simulationId}", func(r chi.Router) {
+			// DELETE /simulations/{simulationId}
+			r.Delete("/", sims.Delete())
+			// POST /simulations/{simulationId}/configure-prey
+			r.Post("/configure-prey", sims.With(func(sim *handler.Simulation) http.HandlerFunc { return sim.Hunter.ConfigurePrey }))
+			// POST /simulations/{simulationId}/configure-hunter
+			r.Post("/configure-hunter", sims.With(func(sim *handler.Simulation) http.HandlerFunc { return sim.Hunter.ConfigureHunter() }))
+			// POST /simulations/{simulationId}/hunt
+			r.Post("/hunt", sims.With(func(sim *handler.Simulation) http.HandlerFunc { return sim.Hunter.Hunt() }))
+			// POST /simulations/{simulationId}/hunt/batch
+			r.Post("/hunt/batch", sims.With(func(sim *handler.Simulation) http.HandlerFunc { return sim.Batch.Hunt() }))
+			// GET /simulations/{simulationId}/trajectories/{id}
+			r.Get("/trajectories/{id}", sims.With(func(sim *handler.Simulation) http.HandlerFunc { return sim.Hunter.Trajectory() }))
+		})
+	})
+
+	return
+}
+
+// newSimulation builds a simulation with its own hunter, prey, simulators and arena
+// - the hunter is a white shark and the prey a tuna, or the first ones of the scenario
+func (a *ApplicationDefault) newSimulation() (sim *handler.Simulation, err error) {
+	// - arena and catch simulators
+	ar, sm, smBatch, err := a.simulators()
+	if err != nil {
+		return
+	}
+	// - hunter
+	var ht hunter.Hunter = hunter.NewWhiteShark(hunter.ConfigWhiteShark{
+		Speed:     0.0,
+		Position:  &positioner.Position{X: 0.0, Y: 0.0, Z: 0.0},
+		Simulator: sm,
 	})
+	// - prey
+	pr := prey.NewTuna(0.0, &positioner.Position{X: 0.0, Y: 0.0, Z: 0.0})
+	// - the first hunter and prey of the scenario take their place
+	if a.scenario != nil {
+		hunters, preys := a.scenario.NewSubjects(sm)
+		if len(hunters) > 0 {
+			ht = hunters[0]
+		}
+		if len(preys) > 0 {
+			pr = preys[0]
+		}
+	}
+
+	sim = &handler.Simulation{
+		Hunter: handler.NewHunter(ht, pr, sm, ar),
+		Batch:  handler.NewBatch(smBatch, ar),
+	}
+	return
+}
 
+// addSubjects adds the hunters and the preys of the scenario to their collections, under their ids
+func (a *ApplicationDefault) addSubjects(hunters, preys *handler.Subjects, sm simulator.CatchSimulator) (err error) {
+	scenarioHunters, scenarioPreys := a.scenario.NewSubjects(sm)
+	for i, ht := range scenarioHunters {
+		id := a.scenario.Hunters[i].ID
+		if err = hunters.Add(id, ht); err != nil {
+			err = fmt.Errorf("hunter %q of the scenario: %w", id, err)
+			return
+		}
+	}
+	for i, pr := range scenarioPreys {
+		id := a.scenario.Preys[i].ID
+		if err = preys.Add(id, pr); err != nil {
+			err = fmt.Errorf("prey %q of the scenario: %w", id, err)
+			return
+		}
+	}
+	return
+}
+
+// simulators returns the arena, the catch simulator and the catch simulator of the batches of hunts
+// - the ones of the scenario, if there is one
+func (a *ApplicationDefault) simulators() (ar *positioner.Arena, sm, smBatch simulator.CatchSimulator, err error) {
+	if a.scenario != nil {
+		ps := a.scenario.NewPositioner()
+		ar = a.scenario.NewArena()
+		sm = a.scenario.NewSimulator(ps, ar, true)
+		smBatch = a.scenario.NewSimulator(ps, ar, false)
+		return
+	}
+
+	// - positioner
+	ps, err := positioner.NewPositioner(a.positioner)
+	if err != nil {
+		return
+	}
+	// - arena: where hunters and preys live (the whole Earth for geographic positions)
+	ar = positioner.NewArenaDefault()
+	if a.positioner.Metric == positioner.MetricHaversine {
+		ar = positioner.NewArenaGeo()
+	}
+	// - catch simulator
+	sm = simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+		MaxTimeToCatch: 100.0,
+		Positioner:     ps,
+		Record:         true,
+		Arena:          ar,
+	})
+	// - catch simulator of the batches of hunts (they do not keep the trajectories)
+	smBatch = simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+		MaxTimeToCatch: 100.0,
+		Positioner:     ps,
+		Arena:          ar,
+	})
 	return
 }
 
@@ -0,0 +1,78 @@
+package application
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/scenario"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pod is a scenario with more than one hunter and more than one prey
+const pod = `name: pod
+seed: 3
+hunters:
+  - id: jaws
+    species: white-shark
+    speed: 10
+  - id: willy
+    species: orca
+preys:
+  - id: nemo
+    species: sardine
+  - id: dory
+    species: tuna
+  - id: flash
+    species: tuna
+`
+
+func TestApplicationDefault_SetUp_Scenario(t *testing.T) {
+	// arrange
+	sc, err := scenario.Load(strings.NewReader(pod))
+	require.NoError(t, err)
+	app := NewApplicationDefault(ConfigApplicationDefault{Scenario: sc})
+	err = app.SetUp()
+	require.NoError(t, err)
+	defer app.TearDown()
+
+	// ids returns the ids of the subjects of a collection
+	ids := func(path string) (ids []string) {
+		res := httptest.NewRecorder()
+		app.rt.ServeHTTP(res, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, res.Code)
+		var body []struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		for _, s := range body {
+			ids = append(ids, s.ID)
+		}
+		return
+	}
+
+	t.Run("every subject of the scenario is in its collection", func(t *testing.T) {
+		// act
+		hunters, preys := ids("/hunters"), ids("/prey")
+
+		// assert
+		require.Equal(t, []string{"jaws", "willy"}, hunters)
+		require.Equal(t, []string{"nemo", "dory", "flash"}, preys)
+	})
+}
+
+func TestApplicationDefault_SetUp_Failure(t *testing.T) {
+	// arrange
+	app := NewApplicationDefault(ConfigApplicationDefault{Positioner: positioner.ConfigPositioner{Metric: "taxicab"}})
+
+	// act
+	err := app.SetUp()
+
+	// assert
+	// -> the simulations are not swept: nothing is left running
+	require.ErrorIs(t, err, positioner.ErrMetricUnknown)
+	require.Nil(t, app.sims)
+}
@@ -0,0 +1,22 @@
+package environment
+
+import "testdoubles/internal/positioner"
+
+// NewCurrentConstant creates a new CurrentConstant
+func NewCurrentConstant(velocity positioner.Velocity) (c *CurrentConstant) {
+	c = &CurrentConstant{velocity: velocity}
+	return
+}
+
+// CurrentConstant is an implementation of Current
+// The water flows with the same velocity everywhere
+type CurrentConstant struct {
+	// velocity of the water in m/s
+	velocity positioner.Velocity
+}
+
+// At returns the velocity of the water
+func (c *CurrentConstant) At(position positioner.Position) (velocity positioner.Velocity) {
+	velocity = c.velocity
+	return
+}
@@ -0,0 +1,137 @@
+package environment
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"testdoubles/internal/positioner"
+)
+
+// ErrGridInvalid is returned when a grid of currents is not valid or its file can not be parsed
+var ErrGridInvalid = errors.New("invalid current grid")
+
+// ConfigCurrentGrid is the configuration for CurrentGrid
+type ConfigCurrentGrid struct {
+	// Origin is the corner of the grid with the lowest coordinates
+	Origin positioner.Position
+	// CellSize is the size of each side of the cells in meters
+	CellSize float64
+	// Cells is the number of cells along X, Y and Z
+	Cells [3]int
+	// Velocities of the water in each cell in m/s, X first, then Y, then Z
+	Velocities []positioner.Velocity
+}
+
+// NewCurrentGrid creates a new CurrentGrid
+func NewCurrentGrid(cfg ConfigCurrentGrid) (c *CurrentGrid, err error) {
+	if cfg.CellSize <= 0 {
+		err = fmt.Errorf("%w: cell size must be positive", ErrGridInvalid)
+		return
+	}
+	if cfg.Cells[0] <= 0 || cfg.Cells[1] <= 0 || cfg.Cells[2] <= 0 {
+		err = fmt.Errorf("%w: the grid needs at least one cell along each axis", ErrGridInvalid)
+		return
+	}
+	if n := cfg.Cells[0] * cfg.Cells[1] * cfg.Cells[2]; len(cfg.Velocities) != n {
+		err = fmt.Errorf("%w: the grid has %d velocities, expected %d", ErrGridInvalid, len(cfg.Velocities), n)
+		return
+	}
+
+	c = &CurrentGrid{
+		origin:     cfg.Origin,
+		cellSize:   cfg.CellSize,
+		cells:      cfg.Cells,
+		velocities: cfg.Velocities,
+	}
+	return
+}
+
+// LoadCurrentGrid reads a grid of currents from a text file
+// - "origin x y z", "cell size" and "size nx ny nz" must come before the velocities
+// - "v vx vy vz" is the velocity of a cell, X first, then Y, then Z
+// - empty lines and lines starting with # are ignored
+func LoadCurrentGrid(r io.Reader) (c *CurrentGrid, err error) {
+	var cfg ConfigCurrentGrid
+	// header lines read so far
+	seen := map[string]bool{}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		var values []float64
+		values, err = parseFloats(fields[1:])
+		if err != nil {
+			err = fmt.Errorf("%w: line %d: %v", ErrGridInvalid, line, err)
+			return
+		}
+		switch {
+		case fields[0] == "origin" && len(values) == 3:
+			cfg.Origin = positioner.Position{X: values[0], Y: values[1], Z: values[2]}
+		case fields[0] == "cell" && len(values) == 1:
+			cfg.CellSize = values[0]
+		case fields[0] == "size" && len(values) == 3:
+			cfg.Cells = [3]int{int(values[0]), int(values[1]), int(values[2])}
+		case fields[0] == "v" && len(values) == 3:
+			if !seen["origin"] || !seen["cell"] || !seen["size"] {
+				err = fmt.Errorf("%w: line %d: origin, cell and size must come before the velocities", ErrGridInvalid, line)
+				return
+			}
+			cfg.Velocities = append(cfg.Velocities, positioner.Velocity{X: values[0], Y: values[1], Z: values[2]})
+		default:
+			err = fmt.Errorf("%w: line %d: unknown line %q", ErrGridInvalid, line, scanner.Text())
+			return
+		}
+		seen[fields[0]] = true
+	}
+	err = scanner.Err()
+	if err != nil {
+		return
+	}
+
+	c, err = NewCurrentGrid(cfg)
+	return
+}
+
+// CurrentGrid is an implementation of Current
+// The velocity of the water is sampled in the cells of a grid, like the output of an ocean model
+// - each position takes the velocity of its cell, positions out of the grid take the one of the closest cell
+type CurrentGrid struct {
+	// origin is the corner of the grid
+	origin positioner.Position
+	// cellSize is the size of each side of the cells in meters
+	cellSize float64
+	// cells is the number of cells along each axis
+	cells [3]int
+	// velocities of the water in each cell
+	velocities []positioner.Velocity
+}
+
+// At returns the velocity of the water in the cell of the position
+func (c *CurrentGrid) At(position positioner.Position) (velocity positioner.Velocity) {
+	var i [3]int
+	for axis, v := range []float64{position.X - c.origin.X, position.Y - c.origin.Y, position.Z - c.origin.Z} {
+		i[axis] = int(math.Max(0, math.Min(float64(c.cells[axis]-1), math.Floor(v/c.cellSize))))
+	}
+	velocity = c.velocities[i[0]+c.cells[0]*(i[1]+c.cells[1]*i[2])]
+	return
+}
+
+// parseFloats parses the numbers of a line
+func parseFloats(fields []string) (values []float64, err error) {
+	values = make([]float64, len(fields))
+	for i, f := range fields {
+		values[i], err = strconv.ParseFloat(f, 64)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,38 @@
+package environment
+
+import "testdoubles/internal/positioner"
+
+// Layer is a layer of water between 2 depths that flows with the same velocity
+type Layer struct {
+	// MinZ and MaxZ are the bounds of the layer along Z (in meters)
+	MinZ float64
+	MaxZ float64
+	// Velocity of the water in the layer in m/s
+	Velocity positioner.Velocity
+}
+
+// NewCurrentLayered creates a new CurrentLayered
+func NewCurrentLayered(layers []Layer) (c *CurrentLayered) {
+	c = &CurrentLayered{layers: layers}
+	return
+}
+
+// CurrentLayered is an implementation of Current
+// The water flows in layers by depth (Z), like a surface current over still deep water
+// - where layers overlap, the first one wins
+// - out of every layer, the water is still
+type CurrentLayered struct {
+	// layers of water
+	layers []Layer
+}
+
+// At returns the velocity of the layer of water at the depth of the position
+func (c *CurrentLayered) At(position positioner.Position) (velocity positioner.Velocity) {
+	for _, l := range c.layers {
+		if position.Z >= l.MinZ && position.Z <= l.MaxZ {
+			velocity = l.Velocity
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,145 @@
+package environment_test
+
+import (
+	"strings"
+	"testdoubles/internal/environment"
+	"testdoubles/internal/positioner"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// grid is a current grid file of 2x1x2 cells of 10 meters
+const grid = `# surface flows east, the bottom flows west
+origin 0 0 0
+cell 10
+size 2 1 2
+
+v 1 0 0
+v 2 0 0
+v -1 0 0
+v -2 0 0
+`
+
+// Tests for the implementations of Current
+func TestCurrent_At(t *testing.T) {
+	gridCurrent, err := environment.LoadCurrentGrid(strings.NewReader(grid))
+	require.NoError(t, err)
+
+	type testCase struct {
+		name     string
+		current  environment.Current
+		position positioner.Position
+		velocity positioner.Velocity
+	}
+
+	layered := environment.NewCurrentLayered([]environment.Layer{
+		{MinZ: 0, MaxZ: 10, Velocity: positioner.Velocity{Y: 1}},
+		{MinZ: 10, MaxZ: 50, Velocity: positioner.Velocity{Y: -1}},
+	})
+
+	cases := []testCase{
+		// case 1: constant
+		{
+			name:     "constant",
+			current:  environment.NewCurrentConstant(positioner.Velocity{X: 1, Y: 2, Z: 3}),
+			position: positioner.Position{X: 100, Y: 200, Z: 300},
+			velocity: positioner.Velocity{X: 1, Y: 2, Z: 3},
+		},
+		// case 2: layered - in the first layer that matches
+		{
+			name:     "layered - first layer",
+			current:  layered,
+			position: positioner.Position{Z: 10},
+			velocity: positioner.Velocity{Y: 1},
+		},
+		// case 3: layered - in the second layer
+		{
+			name:     "layered - second layer",
+			current:  layered,
+			position: positioner.Position{Z: 30},
+			velocity: positioner.Velocity{Y: -1},
+		},
+		// case 4: layered - out of every layer
+		{
+			name:     "layered - still water",
+			current:  layered,
+			position: positioner.Position{Z: 60},
+			velocity: positioner.Velocity{},
+		},
+		// case 5: grid - inside a cell
+		{
+			name:     "grid - inside a cell",
+			current:  gridCurrent,
+			position: positioner.Position{X: 15, Y: 5, Z: 15},
+			velocity: positioner.Velocity{X: -2},
+		},
+		// case 6: grid - out of the grid takes the closest cell
+		{
+			name:     "grid - out of the grid",
+			current:  gridCurrent,
+			position: positioner.Position{X: -50, Y: 100, Z: -5},
+			velocity: positioner.Velocity{X: 1},
+		},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// act
+			velocity := c.current.At(c.position)
+
+			// assert
+			require.Equal(t, c.velocity, velocity)
+		})
+	}
+}
+
+// Tests for LoadCurrentGrid
+func TestLoadCurrentGrid(t *testing.T) {
+	type testCase struct {
+		name   string
+		input  string
+		errMsg string
+	}
+
+	cases := []testCase{
+		// case 1: velocities before the header
+		{
+			name:   "velocities before the header",
+			input:  "origin 0 0 0\nv 1 0 0\n",
+			errMsg: "invalid current grid: line 2: origin, cell and size must come before the velocities",
+		},
+		// case 2: not a number
+		{
+			name:   "not a number",
+			input:  "origin 0 0 0\ncell ten\n",
+			errMsg: "invalid current grid: line 2: strconv.ParseFloat: parsing \"ten\": invalid syntax",
+		},
+		// case 3: unknown line
+		{
+			name:   "unknown line",
+			input:  "# grid\norigin 0 0\n",
+			errMsg: "invalid current grid: line 2: unknown line \"origin 0 0\"",
+		},
+		// case 4: missing velocities
+		{
+			name:   "missing velocities",
+			input:  "origin 0 0 0\ncell 10\nsize 2 1 1\nv 1 0 0\n",
+			errMsg: "invalid current grid: the grid has 1 velocities, expected 2",
+		},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// act
+			current, err := environment.LoadCurrentGrid(strings.NewReader(c.input))
+
+			// assert
+			require.Nil(t, current)
+			require.ErrorIs(t, err, environment.ErrGridInvalid)
+			require.EqualError(t, err, c.errMsg)
+		})
+	}
+}
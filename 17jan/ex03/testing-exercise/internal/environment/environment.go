@@ -0,0 +1,10 @@
+package environment
+
+import "testdoubles/internal/positioner"
+
+// Current is an interface that represents the flow of the water in the arena
+// It carries the subjects along on each step of a stepped simulation, as much as their drag says
+type Current interface {
+	// At returns the velocity of the water at a position (in m/s)
+	At(position positioner.Position) (velocity positioner.Velocity)
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+	"testdoubles/internal/species"
+	"testdoubles/platform/web/request"
+	"testdoubles/platform/web/response"
+	"time"
+)
+
+// NewBatch returns a new Batch handler.
+// - arena is where the subjects of the batches can start (nil for the default arena)
+func NewBatch(sm simulator.CatchSimulator, arena *positioner.Arena) *Batch {
+	if arena == nil {
+		arena = positioner.NewArenaDefault()
+	}
+	return &Batch{sm: sm, arena: arena}
+}
+
+// Batch returns handlers to run batches of random hunts.
+type Batch struct {
+	// sm is the simulator that runs each hunt of a batch
+	sm simulator.CatchSimulator
+	// arena is where hunters and preys live
+	arena *positioner.Arena
+}
+
+var (
+	// ErrBatchRunsOutOfRange is returned when the number of runs of a batch is not valid
+	ErrBatchRunsOutOfRange = errors.New("runs out of range")
+	// ErrBatchWorkersOutOfRange is returned when the number of workers of a batch is not valid
+	ErrBatchWorkersOutOfRange = errors.New("workers out of range")
+	// ErrBatchBinsOutOfRange is returned when the number of bins of the histograms of a batch is not valid
+	ErrBatchBinsOutOfRange = errors.New("bins out of range")
+	// ErrBatchRangeInvalid is returned when the min of a range is greater than its max
+	ErrBatchRangeInvalid = errors.New("min is greater than max")
+)
+
+const (
+	// maxBatchRuns is the max number of hunts of a batch
+	maxBatchRuns = 100000
+	// maxBatchBins is the max number of bins of the histograms of a batch
+	maxBatchBins = 1000
+)
+
+// maxBatchWorkers is the max number of hunts of a batch run at the same time
+var maxBatchWorkers = 4 * runtime.NumCPU()
+
+var (
+	// defaultBatchHunterSpeed is the speed of the hunter of a batch, the same as CreateWhiteShark
+	defaultBatchHunterSpeed = speedRange(species.Default[species.WhiteShark])
+	// defaultBatchPreySpeed is the speed of the prey of a batch, the same as CreateTuna
+	defaultBatchPreySpeed = speedRange(species.Default[species.Tuna])
+)
+
+// DistributionJSON is an struct that represents the distribution of a subject of a batch in JSON format.
+// - a missing speed takes the default of the subject, a missing position takes the whole arena
+type DistributionJSON struct {
+	Speed    *simulator.Range  `json:"speed"`
+	Position *simulator.Volume `json:"position"`
+}
+
+// RequestBodyBatch is an struct to run a batch of hunts in JSON format.
+type RequestBodyBatch struct {
+	Runs    int              `json:"runs"`
+	Seed    *int64           `json:"seed"`
+	Workers int              `json:"workers"`
+	Bins    int              `json:"bins"`
+	Hunter  DistributionJSON `json:"hunter"`
+	Prey    DistributionJSON `json:"prey"`
+}
+
+// Example
+// curl -X POST http://localhost:8080/hunter/hunt/batch \
+// -H "Content-Type: application/json" \
+// -d '{
+//   "runs": 1000,
+//   "seed": 42,
+//   "hunter": {"speed": {"min": 15, "max": 159}},
+//   "prey": {"speed": {"min": 15, "max": 267}}
+// }'
+
+// Hunt runs a batch of random hunts and returns its summary.
+func (b *Batch) Hunt() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Println("call Batch Hunt")
+
+		// request
+		var batchConfig RequestBodyBatch
+		err := request.JSON(r, &batchConfig)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+			return
+		}
+		err = validateBatch(batchConfig.Runs, batchConfig.Workers, batchConfig.Bins)
+		if err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "Configuração do lote inválida: "+err.Error())
+			return
+		}
+		hunter, err := b.distributionOf(batchConfig.Hunter, defaultBatchHunterSpeed)
+		if err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "Configuração do caçador inválida: "+err.Error())
+			return
+		}
+		prey, err := b.distributionOf(batchConfig.Prey, defaultBatchPreySpeed)
+		if err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "Configuração da presa inválida: "+err.Error())
+			return
+		}
+		seed := time.Now().UnixNano()
+		if batchConfig.Seed != nil {
+			seed = *batchConfig.Seed
+		}
+
+		// - no more workers than runs (zero takes the default of the simulator: the number of CPUs)
+		workers := batchConfig.Workers
+		if workers == 0 {
+			workers = runtime.NumCPU()
+		}
+		if workers > batchConfig.Runs {
+			workers = batchConfig.Runs
+		}
+
+		// process
+		mc := simulator.NewMonteCarlo(&simulator.ConfigMonteCarlo{
+			Simulator: b.sm,
+			Hunter:    hunter,
+			Prey:      prey,
+			Runs:      batchConfig.Runs,
+			Workers:   workers,
+			Seed:      seed,
+			Bins:      batchConfig.Bins,
+		})
+		summary := mc.Run()
+
+		// response
+		response.JSON(w, http.StatusOK, summary)
+	}
+}
+
+// validateBatch validates the number of runs, of workers and of bins of a batch
+// - zero workers or bins take the defaults of the simulator
+func validateBatch(runs, workers, bins int) (err error) {
+	if runs < 1 || runs > maxBatchRuns {
+		err = fmt.Errorf("%w: runs must be between 1 and %d", ErrBatchRunsOutOfRange, maxBatchRuns)
+		return
+	}
+	if workers < 0 || workers > maxBatchWorkers {
+		err = fmt.Errorf("%w: workers must be between 0 and %d", ErrBatchWorkersOutOfRange, maxBatchWorkers)
+		return
+	}
+	if bins < 0 || bins > maxBatchBins {
+		err = fmt.Errorf("%w: bins must be between 0 and %d", ErrBatchBinsOutOfRange, maxBatchBins)
+		return
+	}
+	return
+}
+
+// distributionOf converts the distribution of a subject from JSON format, with the defaults for missing fields
+// - the volume of the positions must be inside the arena
+func (b *Batch) distributionOf(d DistributionJSON, speed simulator.Range) (dist simulator.Distribution, err error) {
+	if d.Speed == nil {
+		d.Speed = &speed
+	}
+	if d.Position == nil {
+		d.Position = &simulator.Volume{Min: b.arena.Min(), Max: b.arena.Max()}
+	}
+
+	if d.Speed.Min < 0 {
+		err = ErrSubjectSpeedNegative
+		return
+	}
+	if d.Speed.Min > d.Speed.Max ||
+		d.Position.Min.X > d.Position.Max.X ||
+		d.Position.Min.Y > d.Position.Max.Y ||
+		d.Position.Min.Z > d.Position.Max.Z {
+		err = ErrBatchRangeInvalid
+		return
+	}
+	for _, corner := range []positioner.Position{d.Position.Min, d.Position.Max} {
+		err = b.arena.Validate(&corner)
+		if err != nil {
+			return
+		}
+	}
+
+	dist = simulator.Distribution{Speed: *d.Speed, Position: *d.Position}
+	return
+}
+
+// speedRange returns the range of the speeds of a species
+func speedRange(p species.Profile) (speed simulator.Range) {
+	speed = simulator.Range{Min: p.Speed.Min, Max: p.Speed.Max}
+	return
+}
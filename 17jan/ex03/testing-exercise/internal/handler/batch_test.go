@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch_Hunt(t *testing.T) {
+	// arrange
+	sm := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+		MaxTimeToCatch: 100,
+		Positioner:     positioner.NewPositionerDefault(),
+	})
+	hd := NewBatch(sm, nil).Hunt()
+
+	t.Run("success - summary of the batch", func(t *testing.T) {
+		// act
+		body := `{
+			"runs": 10,
+			"seed": 7,
+			"hunter": {"speed": {"min": 10, "max": 10}, "position": {"min": {"X": 0, "Y": 0, "Z": 0}, "max": {"X": 0, "Y": 0, "Z": 0}}},
+			"prey": {"speed": {"min": 5, "max": 5}, "position": {"min": {"X": 100, "Y": 0, "Z": 0}, "max": {"X": 100, "Y": 0, "Z": 0}}}
+		}`
+		req := httptest.NewRequest(http.MethodPost, "/hunter/hunt/batch", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		hd(res, req)
+
+		// assert
+		expectedBody := `{
+			"runs": 10,
+			"seed": 7,
+			"catch_probability": 1,
+			"outcomes": {"caught": 10},
+			"duration": {"count": 10, "min": 20, "max": 20, "mean": 20, "median": 20, "p95": 20, "histogram": [{"from": 20, "to": 20, "count": 10}]},
+			"closest_approach": {"count": 0, "min": 0, "max": 0, "mean": 0, "median": 0, "p95": 0, "histogram": []}
+		}`
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("success - default distributions", func(t *testing.T) {
+		// act
+		req := httptest.NewRequest(http.MethodPost, "/hunter/hunt/batch", strings.NewReader(`{"runs": 100}`))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		hd(res, req)
+
+		// assert
+		var summary simulator.MonteCarloSummary
+		err := json.Unmarshal(res.Body.Bytes(), &summary)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, 100, summary.Runs)
+		assert.Equal(t, 100, summary.Duration.Count+summary.ClosestApproach.Count)
+	})
+
+	t.Run("failure - runs out of range", func(t *testing.T) {
+		// act
+		req := httptest.NewRequest(http.MethodPost, "/hunter/hunt/batch", strings.NewReader(`{"runs": 0}`))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		hd(res, req)
+
+		// assert
+		expectedBody := `{"status": "Unprocessable Entity", "message": "Configuração do lote inválida: runs out of range: runs must be between 1 and 100000"}`
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("failure - workers or bins out of range", func(t *testing.T) {
+		type testCase struct {
+			name         string
+			body         string
+			expectedBody string
+		}
+		cases := []testCase{
+			// case 1: too many workers
+			{name: "workers", body: fmt.Sprintf(`{"runs": 1, "workers": %d}`, maxBatchWorkers+1), expectedBody: fmt.Sprintf(`{"status": "Unprocessable Entity", "message": "Configuração do lote inválida: workers out of range: workers must be between 0 and %d"}`, maxBatchWorkers)},
+			// case 2: too many bins
+			{name: "bins", body: `{"runs": 1, "bins": 1001}`, expectedBody: `{"status": "Unprocessable Entity", "message": "Configuração do lote inválida: bins out of range: bins must be between 0 and 1000"}`},
+			// case 3: negative bins
+			{name: "negative bins", body: `{"runs": 1, "bins": -1}`, expectedBody: `{"status": "Unprocessable Entity", "message": "Configuração do lote inválida: bins out of range: bins must be between 0 and 1000"}`},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				// act
+				req := httptest.NewRequest(http.MethodPost, "/hunter/hunt/batch", strings.NewReader(c.body))
+				req.Header.Set("Content-Type", "application/json")
+				res := httptest.NewRecorder()
+				hd(res, req)
+
+				// assert
+				assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+				assert.JSONEq(t, c.expectedBody, res.Body.String())
+			})
+		}
+	})
+
+	t.Run("failure - invalid speed range", func(t *testing.T) {
+		// act
+		req := httptest.NewRequest(http.MethodPost, "/hunter/hunt/batch", strings.NewReader(`{"runs": 1, "prey": {"speed": {"min": 20, "max": 10}}}`))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		hd(res, req)
+
+		// assert
+		expectedBody := `{"status": "Unprocessable Entity", "message": "Configuração da presa inválida: min is greater than max"}`
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
@@ -1,18 +1,31 @@
 package handler
 
 import (
-	"encoding/json"
+	"errors"
 	"log"
+	"math/rand"
 	"net/http"
+	"strconv"
+	"sync"
 	"testdoubles/internal/hunter"
 	"testdoubles/internal/positioner"
 	"testdoubles/internal/prey"
+	"testdoubles/internal/simulator"
+	"testdoubles/platform/web/request"
 	"testdoubles/platform/web/response"
+	"time"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // NewHunter returns a new Hunter handler.
-func NewHunter(ht hunter.Hunter, pr prey.Prey) *Hunter {
-	return &Hunter{ht: ht, pr: pr}
+// - sm is the simulator the seeded hunts are run with (only needed for them)
+// - arena is where hunters and preys can be configured (nil for the default arena)
+func NewHunter(ht hunter.Hunter, pr prey.Prey, sm simulator.CatchSimulator, arena *positioner.Arena) *Hunter {
+	if arena == nil {
+		arena = positioner.NewArenaDefault()
+	}
+	return &Hunter{ht: ht, pr: pr, sm: sm, arena: arena, trajectories: make(map[string]*simulator.Trajectory)}
 }
 
 // Hunter returns handlers to manage hunting.
@@ -21,6 +34,43 @@ type Hunter struct {
 	ht hunter.Hunter
 	// pr is the Prey interface that the hunter will hunt
 	pr prey.Prey
+	// sm is the simulator of the seeded hunts
+	sm simulator.CatchSimulator
+	// arena is where hunters and preys live
+	arena *positioner.Arena
+
+	// trajectories are the trajectories recorded by the last hunts, by ID
+	trajectories map[string]*simulator.Trajectory
+	// trajectoryIDs are the IDs of the stored trajectories, from the oldest to the newest
+	trajectoryIDs []string
+	// lastTrajectoryID is the last ID given to a trajectory
+	lastTrajectoryID int
+	// mu guards the trajectories
+	mu sync.Mutex
+}
+
+// maxTrajectories is the max number of trajectories kept by the handler (the oldest ones are dropped)
+const maxTrajectories = 100
+
+var (
+	// ErrSubjectPositionRequired is returned when the position of a subject is not given
+	ErrSubjectPositionRequired = errors.New("position is required")
+	// ErrSubjectSpeedNegative is returned when the speed of a subject is negative
+	ErrSubjectSpeedNegative = errors.New("speed can not be negative")
+)
+
+// validateSubject validates the configuration of a subject (hunter or prey) in the arena
+func validateSubject(speed float64, position *positioner.Position, arena *positioner.Arena) (err error) {
+	if position == nil {
+		err = ErrSubjectPositionRequired
+		return
+	}
+	if speed < 0 {
+		err = ErrSubjectSpeedNegative
+		return
+	}
+	err = arena.Validate(position)
+	return
 }
 
 // RequestBodyConfigPrey is an struct to configure the prey for the hunter in JSON format.
@@ -46,18 +96,23 @@ func (h *Hunter) ConfigurePrey(w http.ResponseWriter, r *http.Request) {
 	log.Println("call ConfigurePrey")
 
 	// request
-	var hunterConfig RequestBodyConfigPrey
-	err := json.NewDecoder(r.Body).Decode(&hunterConfig)
+	var preyConfig RequestBodyConfigPrey
+	err := request.JSON(r, &preyConfig)
 	if err != nil {
 		response.Error(w, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
 		return
 	}
+	err = validateSubject(preyConfig.Speed, preyConfig.Position, h.arena)
+	if err != nil {
+		response.Error(w, http.StatusUnprocessableEntity, "Configuração da presa inválida: "+err.Error())
+		return
+	}
 
 	// process
-	h.ht.Configure(hunterConfig.Speed, hunterConfig.Position)
+	h.pr.Configure(preyConfig.Speed, preyConfig.Position)
 
 	// response
-	response.Text(w, http.StatusOK, "A presa está configurada corretamente")
+	response.JSON(w, http.StatusOK, stateToJSON(h.pr))
 }
 
 // RequestBodyConfigHunter is an struct to configure the hunter in JSON format.
@@ -66,24 +121,277 @@ type RequestBodyConfigHunter struct {
 	Position *positioner.Position `json:"position"`
 }
 
+// Example
+// curl -X POST http://localhost:8080/hunter/configure-hunter \
+// -H "Content-Type: application/json" \
+// -d '{
+//   "speed": 10.0,
+//   "position": {
+//     "X": 100.0,
+//     "Y": 0.0,
+//     "Z": 0.0
+//   }
+// }'
+
 // ConfigureHunter configures the hunter.
 func (h *Hunter) ConfigureHunter() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		log.Println("call ConfigureHunter")
+
 		// request
+		var hunterConfig RequestBodyConfigHunter
+		err := request.JSON(r, &hunterConfig)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+			return
+		}
+		err = validateSubject(hunterConfig.Speed, hunterConfig.Position, h.arena)
+		if err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "Configuração do caçador inválida: "+err.Error())
+			return
+		}
 
 		// process
+		h.ht.Configure(hunterConfig.Speed, hunterConfig.Position)
 
 		// response
+		response.JSON(w, http.StatusOK, stateToJSON(h.ht))
 	}
 }
 
+// SubjectJSON is an struct that represents a subject of the hunt in JSON format.
+type SubjectJSON struct {
+	Speed    float64              `json:"speed"`
+	Position *positioner.Position `json:"position"`
+}
+
+// SubjectHuntJSON is an struct that represents a subject of the hunt and how it moved in JSON format.
+type SubjectHuntJSON struct {
+	SubjectJSON
+	Distance      float64              `json:"distance"`
+	FinalPosition *positioner.Position `json:"final_position"`
+}
+
+// RequestBodyHunt is an struct to hunt with a random hunter and prey in JSON format (optional).
+// - the random ones hunt instead of the configured ones, which are left as they are
+type RequestBodyHunt struct {
+	// Seed randomizes the hunter and the prey from this seed, so the same seed replays the same hunt
+	Seed *int64 `json:"seed"`
+	// Random randomizes the hunter and the prey from a new seed (returned in the response)
+	Random bool `json:"random"`
+}
+
+// ResponseBodyHunt is an struct that represents the result of a hunt in JSON format.
+type ResponseBodyHunt struct {
+	Success         bool              `json:"success"`
+	Outcome         simulator.Outcome `json:"outcome"`
+	Reason          string            `json:"reason,omitempty"`
+	Duration        float64           `json:"duration"`
+	ClosestApproach float64           `json:"closest_approach"`
+	Hunter          SubjectHuntJSON   `json:"hunter"`
+	Prey            SubjectHuntJSON   `json:"prey"`
+	// Seed is the seed the hunter and the prey were randomized from, if they were
+	Seed *int64 `json:"seed,omitempty"`
+	// TrajectoryID is the ID to get the trajectory of the hunt from, if it was recorded
+	TrajectoryID string `json:"trajectory_id,omitempty"`
+	// Trajectory is the trajectory of the hunt, only if asked inline
+	Trajectory *simulator.Trajectory `json:"trajectory,omitempty"`
+}
+
+// setResult sets how the hunt went.
+// - the input states are the ones the hunt started from, if the hunter tells them
+func (body *ResponseBodyHunt) setResult(result *simulator.CatchResult) {
+	if result.HunterStart != nil {
+		body.Hunter.SubjectJSON = subjectToJSON(result.HunterStart.Speed, result.HunterStart.Position)
+	}
+	if result.PreyStart != nil {
+		body.Prey.SubjectJSON = subjectToJSON(result.PreyStart.Speed, result.PreyStart.Position)
+	}
+	body.Success = result.Caught()
+	body.Outcome = result.Outcome
+	body.Duration = result.Duration
+	body.ClosestApproach = result.ClosestApproach
+	body.Hunter.Distance = result.HunterDistance
+	body.Hunter.FinalPosition = result.HunterPosition
+	body.Prey.Distance = result.PreyDistance
+	body.Prey.FinalPosition = result.PreyPosition
+}
+
+// Example
+// curl -X POST http://localhost:8080/hunter/hunt
+// curl -X POST http://localhost:8080/hunter/hunt?trajectory=inline
+// curl -X POST http://localhost:8080/hunter/hunt \
+// -H "Content-Type: application/json" \
+// -d '{"seed": 42}'
+
 // Hunt hunts the prey.
 func (h *Hunter) Hunt() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		log.Println("call Hunt")
+
 		// request
+		// - optional body: randomize the hunter and the prey
+		var huntConfig RequestBodyHunt
+		if r.ContentLength != 0 {
+			err := request.JSON(r, &huntConfig)
+			if err != nil {
+				response.Error(w, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+				return
+			}
+		}
+		if huntConfig.Seed == nil && huntConfig.Random {
+			seed := time.Now().UnixNano()
+			huntConfig.Seed = &seed
+		}
+		ht, pr := h.ht, h.pr
+		if huntConfig.Seed != nil {
+			// - a shark and a tuna of their own, so the configured hunter and prey are left as they are
+			// - the same source is used for both, so the seed gives the whole scenario
+			src := rand.NewSource(*huntConfig.Seed)
+			ht = hunter.CreateWhiteShark(h.sm, src)
+			pr = prey.CreateTuna(src)
+		}
+		// - input state (copied, so it is not affected by the hunt), for a hunter that does not tell the one it hunted from
+		body := ResponseBodyHunt{
+			Seed:   huntConfig.Seed,
+			Hunter: SubjectHuntJSON{SubjectJSON: stateToJSON(ht)},
+			Prey:   SubjectHuntJSON{SubjectJSON: stateToJSON(pr)},
+		}
 
 		// process
+		result, err := runHunt(ht, pr)
+		if err != nil && !errors.Is(err, hunter.ErrCanNotHunt) {
+			response.Error(w, http.StatusInternalServerError, "Erro interno ao caçar a presa")
+			return
+		}
 
 		// response
+		body.setResult(result)
+		if result.Trajectory != nil {
+			body.TrajectoryID = h.storeTrajectory(result.Trajectory)
+			if r.URL.Query().Get("trajectory") == "inline" {
+				body.Trajectory = result.Trajectory
+			}
+		}
+		if err != nil {
+			body.Success = false
+			body.Reason = err.Error()
+			response.JSON(w, http.StatusUnprocessableEntity, body)
+			return
+		}
+		response.JSON(w, http.StatusOK, body)
+	}
+}
+
+// Example
+// curl http://localhost:8080/hunter/trajectories/1?format=csv
+
+// trajectoryContentTypes are the content types of the formats a trajectory can be exported to
+var trajectoryContentTypes = map[string]string{
+	"json":    "application/json",
+	"csv":     "text/csv",
+	"geojson": "application/geo+json",
+}
+
+// Trajectory returns the trajectory of a hunt by ID
+// - format is json (default), csv or geojson
+func (h *Hunter) Trajectory() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Println("call Trajectory")
+
+		// request
+		id := chi.URLParam(r, "id")
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = "json"
+		}
+		contentType, ok := trajectoryContentTypes[format]
+		if !ok {
+			response.Error(w, http.StatusBadRequest, "Formato inválido: use json, csv ou geojson")
+			return
+		}
+
+		// process
+		h.mu.Lock()
+		tr, ok := h.trajectories[id]
+		h.mu.Unlock()
+		if !ok {
+			response.Error(w, http.StatusNotFound, "Trajetória não encontrada")
+			return
+		}
+
+		// response
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusOK)
+		var err error
+		switch format {
+		case "json":
+			err = tr.WriteJSON(w)
+		case "csv":
+			err = tr.WriteCSV(w)
+		case "geojson":
+			err = tr.WriteGeoJSON(w)
+		}
+		if err != nil {
+			log.Println("error writing trajectory:", err)
+		}
+	}
+}
+
+// storeTrajectory stores a trajectory and returns its ID
+// - only the last maxTrajectories are kept
+func (h *Hunter) storeTrajectory(tr *simulator.Trajectory) (id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastTrajectoryID++
+	id = strconv.Itoa(h.lastTrajectoryID)
+	h.trajectories[id] = tr
+	h.trajectoryIDs = append(h.trajectoryIDs, id)
+	if len(h.trajectoryIDs) > maxTrajectories {
+		delete(h.trajectories, h.trajectoryIDs[0])
+		h.trajectoryIDs = h.trajectoryIDs[1:]
+	}
+	return
+}
+
+// subject is a hunter or a prey, as the handler reads them
+type subject interface {
+	GetSpeed() (speed float64)
+	GetPosition() (position *positioner.Position)
+}
+
+// runHunt hunts the prey with the hunter
+// - a hunt with no result is an empty hunt, failed unless the hunter says otherwise (like the hunters do with their simulator)
+func runHunt(ht hunter.Hunter, pr prey.Prey) (result *simulator.CatchResult, err error) {
+	result, err = ht.Hunt(pr)
+	if result == nil {
+		result = &simulator.CatchResult{}
+		if err == nil {
+			err = &hunter.HuntError{Reason: hunter.ErrNoResult}
+		}
+	}
+	return
+}
+
+// stateToJSON converts the current state of a subject to JSON format.
+// - read at once if the subject can, so a concurrent configuration does not tear it
+func stateToJSON(sb subject) (s SubjectJSON) {
+	if sn, ok := sb.(simulator.Snapshotter); ok {
+		state := sn.Snapshot()
+		s = subjectToJSON(state.Speed, state.Position)
+		return
+	}
+	s = subjectToJSON(sb.GetSpeed(), sb.GetPosition())
+	return
+}
+
+// subjectToJSON converts the state of a subject to JSON format.
+func subjectToJSON(speed float64, position *positioner.Position) (s SubjectJSON) {
+	s.Speed = speed
+	if position != nil {
+		p := *position
+		s.Position = &p
 	}
+	return
 }
@@ -3,52 +3,586 @@ package handler
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testdoubles/internal/hunter"
 	"testdoubles/internal/positioner"
 	"testdoubles/internal/prey"
 	"testdoubles/internal/simulator"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestHunter_ConfigurePrey(t *testing.T) {
-	requestBody := RequestBodyConfigPrey{
-		Speed: 10.5,
-		Position: &positioner.Position{
-			X: 100,
-			Y: 200,
-		},
+	t.Run("success - prey is configured", func(t *testing.T) {
+		requestBody := RequestBodyConfigPrey{
+			Speed: 10.5,
+			Position: &positioner.Position{
+				X: 100,
+				Y: 200,
+			},
+		}
+		body, _ := json.Marshal(requestBody)
+
+		req, err := http.NewRequest(http.MethodPost, "/hunter/configure-prey", bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("Não foi possível criar a requisição: %v", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+
+		ps := positioner.NewPositionerDefault()
+
+		sm := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+			Positioner: ps,
+		})
+
+		ht := hunter.NewWhiteShark(hunter.ConfigWhiteShark{
+			Speed:     3.0,
+			Position:  &positioner.Position{X: 0.0, Y: 0.0, Z: 0.0},
+			Simulator: sm,
+		})
+
+		pr := prey.NewTuna(0.4, &positioner.Position{X: 0.0, Y: 0.0, Z: 0.0})
+
+		h := NewHunter(ht, pr, sm, nil)
+
+		h.ConfigurePrey(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, `{"speed": 10.5, "position": {"X": 100, "Y": 200, "Z": 0}}`, recorder.Body.String())
+		assert.Equal(t, 10.5, pr.GetSpeed())
+		assert.Equal(t, &positioner.Position{X: 100, Y: 200, Z: 0}, pr.GetPosition())
+		assert.Equal(t, 3.0, ht.GetSpeed())
+	})
+
+	t.Run("failure - content type is not json", func(t *testing.T) {
+		// arrange
+		pr := prey.NewPreyStub()
+		h := NewHunter(hunter.NewHunterMock(), pr, nil, nil)
+
+		// act
+		req := httptest.NewRequest(http.MethodPost, "/hunter/configure-prey", strings.NewReader(`{"speed": 1}`))
+		res := httptest.NewRecorder()
+		h.ConfigurePrey(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status": "Bad Request", "message": "Erro ao decodificar JSON: request content type is not application/json"}`
+		assert.Equal(t, expectedCode, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("failure - invalid configuration", func(t *testing.T) {
+		type testCase struct {
+			name         string
+			body         string
+			expectedBody string
+		}
+		cases := []testCase{
+			{
+				name:         "position is nil",
+				body:         `{"speed": 1}`,
+				expectedBody: `{"status": "Unprocessable Entity", "message": "Configuração da presa inválida: position is required"}`,
+			},
+			{
+				name:         "speed is negative",
+				body:         `{"speed": -1, "position": {"X": 0, "Y": 0, "Z": 0}}`,
+				expectedBody: `{"status": "Unprocessable Entity", "message": "Configuração da presa inválida: speed can not be negative"}`,
+			},
+			{
+				name:         "position is out of the arena",
+				body:         `{"speed": 1, "position": {"X": 0, "Y": 501, "Z": 0}}`,
+				expectedBody: `{"status": "Unprocessable Entity", "message": "Configuração da presa inválida: position is out of the arena: Y must be between 0 and 500"}`,
+			},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				// arrange
+				pr := prey.NewPreyStub()
+				h := NewHunter(hunter.NewHunterMock(), pr, nil, nil)
+
+				// act
+				req := httptest.NewRequest(http.MethodPost, "/hunter/configure-prey", strings.NewReader(c.body))
+				req.Header.Set("Content-Type", "application/json")
+				res := httptest.NewRecorder()
+				h.ConfigurePrey(res, req)
+
+				// assert
+				assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+				assert.JSONEq(t, c.expectedBody, res.Body.String())
+			})
+		}
+	})
+}
+
+func TestHunter_ConfigureHunter(t *testing.T) {
+	t.Run("success - hunter is configured", func(t *testing.T) {
+		// arrange
+		// - hunter: white shark
+		ht := hunter.NewWhiteShark(hunter.ConfigWhiteShark{})
+		// - prey: tuna
+		pr := prey.NewTuna(0.0, &positioner.Position{X: 0.0, Y: 0.0, Z: 0.0})
+		// - handler
+		h := NewHunter(ht, pr, nil, nil)
+		hd := h.ConfigureHunter()
+
+		// act
+		req := httptest.NewRequest(http.MethodPost, "/hunter/configure-hunter", strings.NewReader(
+			`{"speed": 10, "position": {"X": 100, "Y": 0, "Z": 50}}`,
+		))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		hd(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"speed": 10, "position": {"X": 100, "Y": 0, "Z": 50}}`
+		assert.Equal(t, expectedCode, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+		assert.Equal(t, 10.0, ht.GetSpeed())
+		assert.Equal(t, &positioner.Position{X: 100, Y: 0, Z: 50}, ht.GetPosition())
+	})
+
+	t.Run("failure - invalid json", func(t *testing.T) {
+		// arrange
+		ht := hunter.NewHunterMock()
+		h := NewHunter(ht, prey.NewPreyStub(), nil, nil)
+		hd := h.ConfigureHunter()
+
+		// act
+		req := httptest.NewRequest(http.MethodPost, "/hunter/configure-hunter", strings.NewReader(`{"speed": 10`))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		hd(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status": "Bad Request", "message": "Erro ao decodificar JSON: request json invalid. unexpected EOF"}`
+		assert.Equal(t, expectedCode, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+		assert.Equal(t, 0, ht.Calls.Configure)
+	})
+
+	t.Run("failure - speed is negative", func(t *testing.T) {
+		// arrange
+		ht := hunter.NewHunterMock()
+		h := NewHunter(ht, prey.NewPreyStub(), nil, nil)
+		hd := h.ConfigureHunter()
+
+		// act
+		req := httptest.NewRequest(http.MethodPost, "/hunter/configure-hunter", strings.NewReader(
+			`{"speed": -10, "position": {"X": 100, "Y": 0, "Z": 50}}`,
+		))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		hd(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status": "Unprocessable Entity", "message": "Configuração do caçador inválida: speed can not be negative"}`
+		assert.Equal(t, expectedCode, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+		assert.Equal(t, 0, ht.Calls.Configure)
+	})
+}
+
+func TestHunter_Hunt(t *testing.T) {
+	t.Run("success - hunter catches the prey", func(t *testing.T) {
+		// arrange
+		// - hunter: mock
+		ht := hunter.NewHunterMock()
+		ht.GetSpeedFunc = func() (speed float64) { return 10 }
+		ht.GetPositionFunc = func() (position *positioner.Position) { return &positioner.Position{X: 100, Y: 0, Z: 0} }
+		ht.HuntFunc = func(pr prey.Prey) (result *simulator.CatchResult, err error) {
+			result = &simulator.CatchResult{
+				Outcome:         simulator.OutcomeCaught,
+				Duration:        20,
+				HunterDistance:  200,
+				PreyDistance:    100,
+				ClosestApproach: 0,
+				HunterPosition:  &positioner.Position{X: -100, Y: 0, Z: 0},
+				PreyPosition:    &positioner.Position{X: -100, Y: 0, Z: 0},
+			}
+			return
+		}
+		// - prey: stub
+		pr := prey.NewPreyStub()
+		pr.GetSpeedFunc = func() (speed float64) { return 5 }
+		pr.GetPositionFunc = func() (position *positioner.Position) { return &positioner.Position{X: 0, Y: 0, Z: 0} }
+		// - handler
+		h := NewHunter(ht, pr, nil, nil)
+		hd := h.Hunt()
+
+		// act
+		req := httptest.NewRequest(http.MethodPost, "/hunter/hunt", nil)
+		res := httptest.NewRecorder()
+		hd(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{
+			"success": true,
+			"outcome": "caught",
+			"duration": 20,
+			"closest_approach": 0,
+			"hunter": {"speed": 10, "position": {"X": 100, "Y": 0, "Z": 0}, "distance": 200, "final_position": {"X": -100, "Y": 0, "Z": 0}},
+			"prey": {"speed": 5, "position": {"X": 0, "Y": 0, "Z": 0}, "distance": 100, "final_position": {"X": -100, "Y": 0, "Z": 0}}
+		}`
+		expectedHeader := http.Header{"Content-Type": []string{"application/json"}}
+		assert.Equal(t, expectedCode, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+		assert.Equal(t, expectedHeader, res.Header())
+		assert.Equal(t, 1, ht.Calls.Hunt)
+	})
+
+	t.Run("failure - hunter can not catch the prey", func(t *testing.T) {
+		// arrange
+		// - hunter: mock
+		ht := hunter.NewHunterMock()
+		ht.GetSpeedFunc = func() (speed float64) { return 5 }
+		ht.GetPositionFunc = func() (position *positioner.Position) { return &positioner.Position{X: 100, Y: 0, Z: 0} }
+		ht.HuntFunc = func(pr prey.Prey) (result *simulator.CatchResult, err error) {
+			result = &simulator.CatchResult{
+				Outcome:         simulator.OutcomePreyFaster,
+				HunterDistance:  500,
+				PreyDistance:    1000,
+				ClosestApproach: 100,
+				HunterPosition:  &positioner.Position{X: -400, Y: 0, Z: 0},
+				PreyPosition:    &positioner.Position{X: -1000, Y: 0, Z: 0},
+			}
+			err = &hunter.HuntError{Reason: fmt.Errorf("shark can not catch the prey: %w", simulator.ErrPreyFaster)}
+			return
+		}
+		// - prey: stub
+		pr := prey.NewPreyStub()
+		pr.GetSpeedFunc = func() (speed float64) { return 10 }
+		pr.GetPositionFunc = func() (position *positioner.Position) { return &positioner.Position{X: 0, Y: 0, Z: 0} }
+		// - handler
+		h := NewHunter(ht, pr, nil, nil)
+		hd := h.Hunt()
+
+		// act
+		req := httptest.NewRequest(http.MethodPost, "/hunter/hunt", nil)
+		res := httptest.NewRecorder()
+		hd(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{
+			"success": false,
+			"outcome": "prey_faster",
+			"reason": "can not hunt the prey: shark can not catch the prey: prey is faster than the hunter",
+			"duration": 0,
+			"closest_approach": 100,
+			"hunter": {"speed": 5, "position": {"X": 100, "Y": 0, "Z": 0}, "distance": 500, "final_position": {"X": -400, "Y": 0, "Z": 0}},
+			"prey": {"speed": 10, "position": {"X": 0, "Y": 0, "Z": 0}, "distance": 1000, "final_position": {"X": -1000, "Y": 0, "Z": 0}}
+		}`
+		assert.Equal(t, expectedCode, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+		assert.Equal(t, 1, ht.Calls.Hunt)
+	})
+
+	t.Run("failure - the hunt gives no result", func(t *testing.T) {
+		// arrange
+		// - hunter: mock (its default hunt gives no result and no error)
+		ht := hunter.NewHunterMock()
+		// - prey: stub
+		pr := prey.NewPreyStub()
+		// - handler
+		h := NewHunter(ht, pr, nil, nil)
+		hd := h.Hunt()
+
+		// act
+		req := httptest.NewRequest(http.MethodPost, "/hunter/hunt", nil)
+		res := httptest.NewRecorder()
+		hd(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{
+			"success": false,
+			"outcome": "caught",
+			"reason": "can not hunt the prey: the simulator gave no result",
+			"duration": 0,
+			"closest_approach": 0,
+			"hunter": {"speed": 0, "position": null, "distance": 0, "final_position": null},
+			"prey": {"speed": 0, "position": null, "distance": 0, "final_position": null}
+		}`
+		assert.Equal(t, expectedCode, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+		assert.Equal(t, 1, ht.Calls.Hunt)
+	})
+
+	t.Run("failure - unexpected error", func(t *testing.T) {
+		// arrange
+		// - hunter: mock
+		ht := hunter.NewHunterMock()
+		ht.HuntFunc = func(pr prey.Prey) (result *simulator.CatchResult, err error) { return nil, errors.New("internal error") }
+		// - prey: stub
+		pr := prey.NewPreyStub()
+		// - handler
+		h := NewHunter(ht, pr, nil, nil)
+		hd := h.Hunt()
+
+		// act
+		req := httptest.NewRequest(http.MethodPost, "/hunter/hunt", nil)
+		res := httptest.NewRecorder()
+		hd(res, req)
+
+		// assert
+		expectedCode := http.StatusInternalServerError
+		expectedBody := `{"status": "Internal Server Error", "message": "Erro interno ao caçar a presa"}`
+		assert.Equal(t, expectedCode, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+func TestHunter_Trajectory(t *testing.T) {
+	// arrange
+	// - hunter: mock that records a trajectory
+	ht := hunter.NewHunterMock()
+	ht.HuntFunc = func(pr prey.Prey) (result *simulator.CatchResult, err error) {
+		tr := simulator.NewTrajectory()
+		tr.Record(
+			&simulator.Subject{Time: 0, Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}},
+			&simulator.Subject{Time: 0, Speed: 5, Position: &positioner.Position{X: 10, Y: 0, Z: 0}},
+		)
+		result = &simulator.CatchResult{Outcome: simulator.OutcomeCaught, Trajectory: tr}
+		return
 	}
-	body, _ := json.Marshal(requestBody)
+	// - prey: stub
+	pr := prey.NewPreyStub()
+	// - handler
+	h := NewHunter(ht, pr, nil, nil)
+	// - router, to resolve the URL params
+	rt := chi.NewRouter()
+	rt.Post("/hunter/hunt", h.Hunt())
+	rt.Get("/hunter/trajectories/{id}", h.Trajectory())
+
+	t.Run("success - hunt returns the trajectory inline and by ID", func(t *testing.T) {
+		// act
+		req := httptest.NewRequest(http.MethodPost, "/hunter/hunt?trajectory=inline", nil)
+		res := httptest.NewRecorder()
+		rt.ServeHTTP(res, req)
+
+		// assert
+		var body ResponseBodyHunt
+		err := json.Unmarshal(res.Body.Bytes(), &body)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "1", body.TrajectoryID)
+		assert.NotNil(t, body.Trajectory)
+		assert.Len(t, body.Trajectory.Hunter, 1)
+	})
+
+	t.Run("success - trajectory as csv", func(t *testing.T) {
+		// act
+		req := httptest.NewRequest(http.MethodGet, "/hunter/trajectories/1?format=csv", nil)
+		res := httptest.NewRecorder()
+		rt.ServeHTTP(res, req)
+
+		// assert
+		expectedBody := "subject,time,x,y,z,speed,heading_x,heading_y,heading_z\n" +
+			"hunter,0,0,0,0,10,0,0,0\n" +
+			"prey,0,10,0,0,5,0,0,0\n"
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "text/csv", res.Header().Get("Content-Type"))
+		assert.Equal(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("success - trajectory as geojson", func(t *testing.T) {
+		// act
+		req := httptest.NewRequest(http.MethodGet, "/hunter/trajectories/1?format=geojson", nil)
+		res := httptest.NewRecorder()
+		rt.ServeHTTP(res, req)
+
+		// assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "application/geo+json", res.Header().Get("Content-Type"))
+		assert.Contains(t, res.Body.String(), `"LineString"`)
+	})
+
+	t.Run("failure - trajectory not found", func(t *testing.T) {
+		// act
+		req := httptest.NewRequest(http.MethodGet, "/hunter/trajectories/99", nil)
+		res := httptest.NewRecorder()
+		rt.ServeHTTP(res, req)
+
+		// assert
+		expectedBody := `{"status": "Not Found", "message": "Trajetória não encontrada"}`
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("failure - invalid format", func(t *testing.T) {
+		// act
+		req := httptest.NewRequest(http.MethodGet, "/hunter/trajectories/1?format=xml", nil)
+		res := httptest.NewRecorder()
+		rt.ServeHTTP(res, req)
 
-	req, err := http.NewRequest(http.MethodGet, "/hunter/configure-prey", bytes.NewReader(body))
-	if err != nil {
-		t.Fatalf("Não foi possível criar a requisição: %v", err)
+		// assert
+		expectedBody := `{"status": "Bad Request", "message": "Formato inválido: use json, csv ou geojson"}`
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+func TestHunter_Hunt_Seed(t *testing.T) {
+	// hunt runs a hunt with the given request body and returns the response
+	hunt := func(body string) (res *httptest.ResponseRecorder) {
+		// - hunter and prey: real ones, randomized by the seed
+		sm := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+			MaxTimeToCatch: 100,
+			Positioner:     positioner.NewPositionerDefault(),
+		})
+		ht := hunter.NewWhiteShark(hunter.ConfigWhiteShark{Simulator: sm})
+		pr := prey.NewTuna(0, nil)
+		hd := NewHunter(ht, pr, sm, nil).Hunt()
+
+		req := httptest.NewRequest(http.MethodPost, "/hunter/hunt", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		res = httptest.NewRecorder()
+		hd(res, req)
+		return
 	}
 
-	recorder := httptest.NewRecorder()
+	t.Run("success - the same seed replays the same hunt", func(t *testing.T) {
+		// act
+		res1 := hunt(`{"seed": 42}`)
+		res2 := hunt(`{"seed": 42}`)
 
-	ps := positioner.NewPositionerDefault()
+		// assert
+		assert.Equal(t, res1.Code, res2.Code)
+		assert.JSONEq(t, res1.Body.String(), res2.Body.String())
+		assert.Contains(t, res1.Body.String(), `"seed":42`)
+	})
 
-	sm := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
-		Positioner: ps,
+	t.Run("success - a random hunt returns its seed", func(t *testing.T) {
+		// act
+		res := hunt(`{"random": true}`)
+
+		// assert
+		var body ResponseBodyHunt
+		err := json.Unmarshal(res.Body.Bytes(), &body)
+		assert.NoError(t, err)
+		assert.NotNil(t, body.Seed)
+
+		replay := hunt(fmt.Sprintf(`{"seed": %d}`, *body.Seed))
+		assert.JSONEq(t, res.Body.String(), replay.Body.String())
 	})
 
-	ht := hunter.NewWhiteShark(hunter.ConfigWhiteShark{
-		Speed:     3.0,
-		Position:  &positioner.Position{X: 0.0, Y: 0.0, Z: 0.0},
-		Simulator: sm,
+	t.Run("success - a seeded hunt leaves the configured hunter and prey as they are", func(t *testing.T) {
+		// arrange
+		sm := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+			MaxTimeToCatch: 100,
+			Positioner:     positioner.NewPositionerDefault(),
+		})
+		ht := hunter.NewWhiteShark(hunter.ConfigWhiteShark{Speed: 10, Position: &positioner.Position{X: 1}, Simulator: sm})
+		pr := prey.NewTuna(2, &positioner.Position{X: 3})
+		hd := NewHunter(ht, pr, sm, nil).Hunt()
+
+		// act
+		req := httptest.NewRequest(http.MethodPost, "/hunter/hunt", strings.NewReader(`{"seed": 42}`))
+		req.Header.Set("Content-Type", "application/json")
+		res := httptest.NewRecorder()
+		hd(res, req)
+
+		// assert
+		assert.NotEqual(t, http.StatusInternalServerError, res.Code)
+		assert.Equal(t, 10.0, ht.GetSpeed())
+		assert.Equal(t, &positioner.Position{X: 1}, ht.GetPosition())
+		assert.Equal(t, 2.0, pr.GetSpeed())
+		assert.Equal(t, &positioner.Position{X: 3}, pr.GetPosition())
 	})
 
-	pr := prey.NewTuna(0.4, &positioner.Position{X: 0.0, Y: 0.0, Z: 0.0})
+	t.Run("failure - invalid body", func(t *testing.T) {
+		// act
+		res := hunt(`{"seed": "abc"}`)
 
-	h := NewHunter(ht, pr)
+		// assert
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestHunter_Concurrent(t *testing.T) {
+	// run with go test -race: configuring and hunting at the same time must not race
+	// arrange
+	// - hunter and prey: real ones, configured and hunted by many clients
+	sm := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+		MaxTimeToCatch: 100,
+		Positioner:     positioner.NewPositionerDefault(),
+		Record:         true,
+	})
+	ht := hunter.NewWhiteShark(hunter.ConfigWhiteShark{Speed: 10, Position: &positioner.Position{}, Simulator: sm})
+	pr := prey.NewTuna(1, &positioner.Position{X: 10})
+	h := NewHunter(ht, pr, sm, nil)
+	rt := chi.NewRouter()
+	rt.Post("/hunter/configure-prey", h.ConfigurePrey)
+	rt.Post("/hunter/configure-hunter", h.ConfigureHunter())
+	rt.Post("/hunter/hunt", h.Hunt())
+	rt.Get("/hunter/trajectories/{id}", h.Trajectory())
+
+	// serve serves a request on the handler and returns the response
+	serve := func(hd http.Handler, method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		res := httptest.NewRecorder()
+		hd.ServeHTTP(res, req)
+		return res
+	}
+	// seededHunt is the body of a seeded hunt
+	type seededHunt struct {
+		seed int
+		body []byte
+	}
 
-	h.ConfigurePrey(recorder, req)
+	// act
+	const clients, requests = 8, 50
+	codes := make(chan int, clients*requests*4)
+	hunts := make(chan seededHunt, clients*requests)
+	var wg sync.WaitGroup
+	for c := 0; c < clients; c++ {
+		wg.Add(1)
+		go func(c int) {
+			defer wg.Done()
+			for i := 0; i < requests; i++ {
+				codes <- serve(rt, http.MethodPost, "/hunter/configure-prey", fmt.Sprintf(`{"speed": %d, "position": {"X": %d}}`, i%5, c+1)).Code
+				codes <- serve(rt, http.MethodPost, "/hunter/configure-hunter", fmt.Sprintf(`{"speed": %d, "position": {"Y": %d}}`, 5+i%5, c)).Code
+				seed := c*requests + i
+				res := serve(rt, http.MethodPost, "/hunter/hunt", fmt.Sprintf(`{"seed": %d}`, seed))
+				codes <- res.Code
+				hunts <- seededHunt{seed: seed, body: res.Body.Bytes()}
+				codes <- serve(rt, http.MethodGet, "/hunter/trajectories/1", "").Code
+			}
+		}(c)
+	}
+	wg.Wait()
+	close(codes)
+	close(hunts)
 
-	assert.Equal(t, http.StatusOK, recorder.Code)
-	assert.Equal(t, "A presa está configurada corretamente", recorder.Body.String())
+	// assert
+	// - not found: the first trajectory is dropped once there are maxTrajectories newer ones
+	for code := range codes {
+		assert.Contains(t, []int{http.StatusOK, http.StatusUnprocessableEntity, http.StatusNotFound}, code)
+	}
+	// - the input state of a seeded hunt is the one it was hunted from: a replay of the seed on its own gives the same body
+	replay := NewHunter(ht, pr, sm, nil).Hunt()
+	for hunt := range hunts {
+		var got, expected ResponseBodyHunt
+		assert.NoError(t, json.Unmarshal(hunt.body, &got))
+		assert.NoError(t, json.Unmarshal(serve(replay, http.MethodPost, "/hunter/hunt", fmt.Sprintf(`{"seed": %d}`, hunt.seed)).Body.Bytes(), &expected))
+		got.TrajectoryID, expected.TrajectoryID = "", ""
+		assert.Equal(t, expected, got)
+	}
 }
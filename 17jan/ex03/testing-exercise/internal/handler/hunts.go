@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"testdoubles/internal/hunter"
+	"testdoubles/internal/prey"
+	"testdoubles/platform/web/request"
+	"testdoubles/platform/web/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// NewHunts returns a new Hunts handler.
+// - hunters and preys are the collections the hunts take their subjects from
+func NewHunts(hunters, preys *Subjects) *Hunts {
+	return &Hunts{hunters: hunters, preys: preys, hunts: make(map[string]*HuntJSON)}
+}
+
+// Hunts returns handlers to run hunts between the hunters and the preys of the collections, as resources.
+type Hunts struct {
+	// hunters is the collection of the hunters
+	hunters *Subjects
+	// preys is the collection of the preys
+	preys *Subjects
+
+	// hunts are the hunts that were run, by ID
+	hunts map[string]*HuntJSON
+	// ids are the IDs of the hunts, in the order they were run
+	ids []string
+	// lastID is the last ID given to a hunt
+	lastID int
+	// mu guards the hunts
+	mu sync.Mutex
+}
+
+// RequestBodyHuntResource is an struct to run a hunt between a hunter and a prey of the collections in JSON format.
+type RequestBodyHuntResource struct {
+	HunterID string `json:"hunterId"`
+	PreyID   string `json:"preyId"`
+}
+
+// HuntJSON is an struct that represents a hunt that was run in JSON format.
+type HuntJSON struct {
+	ID       string `json:"id"`
+	HunterID string `json:"hunterId"`
+	PreyID   string `json:"preyId"`
+	ResponseBodyHunt
+}
+
+// maxHunts is the max number of hunts kept by the handler (the oldest ones are dropped)
+const maxHunts = 1000
+
+var (
+	// ErrHuntHunterRequired is returned when the hunter of a hunt is not given
+	ErrHuntHunterRequired = errors.New("hunterId is required")
+	// ErrHuntPreyRequired is returned when the prey of a hunt is not given
+	ErrHuntPreyRequired = errors.New("preyId is required")
+)
+
+// Example
+// curl http://localhost:8080/hunts
+
+// List returns the last hunts that were run, in order.
+func (h *Hunts) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Println("call List")
+
+		// process
+		h.mu.Lock()
+		body := make([]*HuntJSON, 0, len(h.ids))
+		for _, id := range h.ids {
+			body = append(body, h.hunts[id])
+		}
+		h.mu.Unlock()
+
+		// response
+		response.JSON(w, http.StatusOK, body)
+	}
+}
+
+// Example
+// curl -X POST http://localhost:8080/hunts \
+// -H "Content-Type: application/json" \
+// -d '{"hunterId": "jaws", "preyId": "1"}'
+
+// Create runs a hunt between a hunter and a prey and returns where it is.
+// - a hunt the hunter can not win is also created: it is how the hunt went
+func (h *Hunts) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Println("call Create")
+
+		// request
+		var body RequestBodyHuntResource
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+			return
+		}
+		switch {
+		case body.HunterID == "":
+			err = ErrHuntHunterRequired
+		case body.PreyID == "":
+			err = ErrHuntPreyRequired
+		}
+		if err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "Caçada inválida: "+err.Error())
+			return
+		}
+		eh, ok := h.hunters.lookup(body.HunterID)
+		if !ok {
+			response.Error(w, http.StatusNotFound, h.hunters.notFound)
+			return
+		}
+		ep, ok := h.preys.lookup(body.PreyID)
+		if !ok {
+			response.Error(w, http.StatusNotFound, h.preys.notFound)
+			return
+		}
+		// - the collections are of hunters and of preys, unless they were mixed up
+		ht, htOk := eh.(hunter.Hunter)
+		pr, prOk := ep.(prey.Prey)
+		if !htOk || !prOk {
+			response.Error(w, http.StatusInternalServerError, "Erro interno ao caçar a presa")
+			return
+		}
+
+		// process
+		// - input state, for a hunter that does not tell the one it hunted from
+		hunt := &HuntJSON{HunterID: body.HunterID, PreyID: body.PreyID}
+		hunt.Hunter.SubjectJSON = stateToJSON(ht)
+		hunt.Prey.SubjectJSON = stateToJSON(pr)
+		result, err := runHunt(ht, pr)
+		if err != nil && !errors.Is(err, hunter.ErrCanNotHunt) {
+			response.Error(w, http.StatusInternalServerError, "Erro interno ao caçar a presa")
+			return
+		}
+		hunt.setResult(result)
+		if err != nil {
+			hunt.Success = false
+			hunt.Reason = err.Error()
+		}
+		h.mu.Lock()
+		h.lastID++
+		hunt.ID = strconv.Itoa(h.lastID)
+		h.hunts[hunt.ID] = hunt
+		h.ids = append(h.ids, hunt.ID)
+		if len(h.ids) > maxHunts {
+			delete(h.hunts, h.ids[0])
+			h.ids = h.ids[1:]
+		}
+		h.mu.Unlock()
+
+		// response
+		w.Header().Set("Location", "/hunts/"+hunt.ID)
+		response.JSON(w, http.StatusCreated, hunt)
+	}
+}
+
+// Example
+// curl http://localhost:8080/hunts/1
+
+// Get returns a hunt by ID.
+func (h *Hunts) Get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Println("call Get")
+
+		// request
+		id := chi.URLParam(r, "id")
+
+		// process
+		h.mu.Lock()
+		hunt, ok := h.hunts[id]
+		h.mu.Unlock()
+		if !ok {
+			response.Error(w, http.StatusNotFound, "Caçada não encontrada")
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, hunt)
+	}
+}
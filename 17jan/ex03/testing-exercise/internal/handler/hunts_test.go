@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// newHuntsRouter returns a router with the routes of the hunters, the preys and the hunts, like the application
+func newHuntsRouter() (rt *chi.Mux) {
+	hunters, preys := newTestHunters(), NewPreys(nil, nil)
+	hunts := NewHunts(hunters, preys)
+
+	rt = chi.NewRouter()
+	rt.Post("/hunters", hunters.Create())
+	rt.Post("/prey", preys.Create())
+	rt.Get("/hunts", hunts.List())
+	rt.Post("/hunts", hunts.Create())
+	rt.Get("/hunts/{id}", hunts.Get())
+	return
+}
+
+func TestHunts_Create(t *testing.T) {
+	// arrange
+	rt := newHuntsRouter()
+	serve(rt, http.MethodPost, "/hunters", `{"id": "jaws", "species": "orca", "speed": 12, "position": {"X": 0}}`)
+	serve(rt, http.MethodPost, "/prey", `{"id": "nemo", "species": "sardine", "speed": 2, "position": {"X": 10}}`)
+	serve(rt, http.MethodPost, "/prey", `{"id": "flash", "species": "tuna", "speed": 100, "position": {"X": 10}}`)
+
+	t.Run("success - the hunter catches the prey", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodPost, "/hunts", `{"hunterId": "jaws", "preyId": "nemo"}`)
+
+		// assert
+		var body HuntJSON
+		err := json.Unmarshal(res.Body.Bytes(), &body)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, res.Code)
+		assert.Equal(t, "/hunts/"+body.ID, res.Header().Get("Location"))
+		assert.Equal(t, "jaws", body.HunterID)
+		assert.Equal(t, "nemo", body.PreyID)
+		assert.True(t, body.Success)
+		assert.Equal(t, 12.0, body.Hunter.Speed)
+		assert.Equal(t, 2.0, body.Prey.Speed)
+	})
+
+	t.Run("success - the hunter can not catch the prey, the hunt is created anyway", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodPost, "/hunts", `{"hunterId": "jaws", "preyId": "flash"}`)
+
+		// assert
+		var body HuntJSON
+		err := json.Unmarshal(res.Body.Bytes(), &body)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, res.Code)
+		assert.False(t, body.Success)
+		assert.NotEmpty(t, body.Reason)
+	})
+
+	t.Run("failure - hunter or prey not found", func(t *testing.T) {
+		type testCase struct {
+			name         string
+			body         string
+			expectedBody string
+		}
+		cases := []testCase{
+			// case 1: hunter not found
+			{name: "hunter", body: `{"hunterId": "bruce", "preyId": "nemo"}`, expectedBody: `{"status": "Not Found", "message": "Caçador não encontrado"}`},
+			// case 2: prey not found
+			{name: "prey", body: `{"hunterId": "jaws", "preyId": "dory"}`, expectedBody: `{"status": "Not Found", "message": "Presa não encontrada"}`},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				// act
+				res := serve(rt, http.MethodPost, "/hunts", c.body)
+
+				// assert
+				assert.Equal(t, http.StatusNotFound, res.Code)
+				assert.JSONEq(t, c.expectedBody, res.Body.String())
+			})
+		}
+	})
+
+	t.Run("failure - prey is required", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodPost, "/hunts", `{"hunterId": "jaws"}`)
+
+		// assert
+		expectedBody := `{"status": "Unprocessable Entity", "message": "Caçada inválida: preyId is required"}`
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+func TestHunts_Create_MixedUp(t *testing.T) {
+	// arrange
+	// - the preys are given as the hunters
+	preys := NewPreys(nil, nil)
+	hunts := NewHunts(preys, preys)
+	rt := chi.NewRouter()
+	rt.Post("/prey", preys.Create())
+	rt.Post("/hunts", hunts.Create())
+	serve(rt, http.MethodPost, "/prey", `{"id": "nemo", "species": "sardine", "speed": 2, "position": {"X": 10}}`)
+
+	// act
+	res := serve(rt, http.MethodPost, "/hunts", `{"hunterId": "nemo", "preyId": "nemo"}`)
+
+	// assert
+	expectedBody := `{"status": "Internal Server Error", "message": "Erro interno ao caçar a presa"}`
+	assert.Equal(t, http.StatusInternalServerError, res.Code)
+	assert.JSONEq(t, expectedBody, res.Body.String())
+}
+
+func TestHunts_Get(t *testing.T) {
+	// arrange
+	rt := newHuntsRouter()
+	serve(rt, http.MethodPost, "/hunters", `{"id": "jaws", "species": "orca", "speed": 12, "position": {"X": 0}}`)
+	serve(rt, http.MethodPost, "/prey", `{"id": "nemo", "species": "sardine", "speed": 2, "position": {"X": 10}}`)
+	created := serve(rt, http.MethodPost, "/hunts", `{"hunterId": "jaws", "preyId": "nemo"}`)
+
+	t.Run("success - hunt found and listed", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodGet, created.Header().Get("Location"), "")
+		list := serve(rt, http.MethodGet, "/hunts", "")
+
+		// assert
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, created.Body.String(), res.Body.String())
+		assert.JSONEq(t, "["+created.Body.String()+"]", list.Body.String())
+	})
+
+	t.Run("failure - hunt not found", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodGet, "/hunts/99", "")
+
+		// assert
+		expectedBody := `{"status": "Not Found", "message": "Caçada não encontrada"}`
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"sync"
+	"testdoubles/platform/web/response"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// defaultSimulationTTL is how long a simulation lives without requests, if no TTL is given
+	defaultSimulationTTL = 30 * time.Minute
+	// defaultMaxSimulations is the max number of live simulations, if no max is given
+	defaultMaxSimulations = 1000
+)
+
+// NewSimulations returns a new Simulations handler.
+// - factory builds each new simulation, with its own hunter, prey, simulators and arena
+// - ttl is how long a simulation lives without requests (0 for the default: 30 minutes)
+// - max is the max number of live simulations (0 for the default: 1000)
+func NewSimulations(factory func() (*Simulation, error), ttl time.Duration, max int) *Simulations {
+	// default config
+	if ttl <= 0 {
+		ttl = defaultSimulationTTL
+	}
+	if max <= 0 {
+		max = defaultMaxSimulations
+	}
+	return &Simulations{factory: factory, ttl: ttl, max: max, now: time.Now, simulations: make(map[string]*Simulation)}
+}
+
+// Simulation is an isolated simulation: the handlers of its own hunter, prey, simulators and arena
+type Simulation struct {
+	// Hunter handles the configuration of the hunter and the prey, the hunts and their trajectories
+	Hunter *Hunter
+	// Batch handles the batches of random hunts
+	Batch *Batch
+
+	// lastUsed is when the simulation got its last request
+	lastUsed time.Time
+}
+
+// Simulations returns handlers to create simulations and to route the requests of each one to its own handlers.
+// Each client works on its own simulation, so clients do not overwrite each other
+type Simulations struct {
+	// factory builds each new simulation
+	factory func() (*Simulation, error)
+	// ttl is how long a simulation lives without requests
+	ttl time.Duration
+	// max is the max number of live simulations
+	max int
+	// now returns the current time (replaced in tests)
+	now func() time.Time
+
+	// simulations are the live simulations, by ID
+	simulations map[string]*Simulation
+	// reserved is the number of simulations being built, that already take their room
+	reserved int
+	// stop stops the sweeping of the expired simulations (nil if it is not running)
+	stop chan struct{}
+	// mu guards the simulations and the sweeping
+	mu sync.Mutex
+}
+
+// ResponseBodySimulation is an struct that represents a simulation in JSON format.
+type ResponseBodySimulation struct {
+	ID string `json:"id"`
+	// TTL is how many seconds the simulation lives without requests
+	TTL float64 `json:"ttl"`
+}
+
+// Example
+// curl -X POST http://localhost:8080/simulations
+
+// Create creates a new simulation and returns its ID
+// - there is no room for it while the max number of simulations are live
+// - its room is reserved before it is built, so no simulation is built when there is none
+func (s *Simulations) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Println("call Create")
+
+		// process
+		// - reserve the room of the simulation: the expired simulations make room, if they were not swept yet
+		s.mu.Lock()
+		if len(s.simulations)+s.reserved >= s.max {
+			s.sweep(s.now())
+		}
+		if len(s.simulations)+s.reserved >= s.max {
+			s.mu.Unlock()
+			response.Error(w, http.StatusServiceUnavailable, "Limite de simulações atingido, tente mais tarde")
+			return
+		}
+		s.reserved++
+		s.mu.Unlock()
+		// - build it, then it takes its room
+		sim, err := s.factory()
+		var id string
+		if err == nil {
+			id, err = newSimulationID()
+		}
+		s.mu.Lock()
+		s.reserved--
+		if err != nil {
+			s.mu.Unlock()
+			response.Error(w, http.StatusInternalServerError, "Erro interno ao criar a simulação")
+			return
+		}
+		sim.lastUsed = s.now()
+		s.simulations[id] = sim
+		s.mu.Unlock()
+
+		// response
+		w.Header().Set("Location", "/simulations/"+id)
+		response.JSON(w, http.StatusCreated, ResponseBodySimulation{ID: id, TTL: s.ttl.Seconds()})
+	}
+}
+
+// Example
+// curl -X DELETE http://localhost:8080/simulations/{id}
+
+// Delete deletes a simulation before it expires
+func (s *Simulations) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Println("call Delete")
+
+		// request
+		id := chi.URLParam(r, "simulationId")
+
+		// process
+		s.mu.Lock()
+		sim, ok := s.simulations[id]
+		ok = ok && !s.expired(sim, s.now())
+		delete(s.simulations, id)
+		s.mu.Unlock()
+		if !ok {
+			response.Error(w, http.StatusNotFound, "Simulação não encontrada")
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusNoContent, nil)
+	}
+}
+
+// Example
+// r.Post("/configure-prey", sims.With(func(sim *handler.Simulation) http.HandlerFunc { return sim.Hunter.ConfigurePrey }))
+
+// With routes a request to the handler of the simulation of the URL param simulationId
+// - the request keeps the simulation alive for another TTL
+func (s *Simulations) With(route func(sim *Simulation) http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id := chi.URLParam(r, "simulationId")
+
+		// process
+		sim, ok := s.get(id)
+		if !ok {
+			response.Error(w, http.StatusNotFound, "Simulação não encontrada")
+			return
+		}
+
+		// response
+		route(sim)(w, r)
+	}
+}
+
+// get returns a live simulation by ID and keeps it alive
+// - an expired simulation is dropped, even if it was not swept yet
+func (s *Simulations) get(id string) (sim *Simulation, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	sim, ok = s.simulations[id]
+	if ok && s.expired(sim, now) {
+		delete(s.simulations, id)
+		sim, ok = nil, false
+	}
+	if ok {
+		sim.lastUsed = now
+	}
+	return
+}
+
+// StartSweeping drops the expired simulations on every tick of interval (the TTL if it is 0), until StopSweeping
+// - nothing happens if it is already sweeping
+func (s *Simulations) StartSweeping(interval time.Duration) {
+	if interval <= 0 {
+		interval = s.ttl
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return
+	}
+	stop := make(chan struct{})
+	s.stop = stop
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.mu.Lock()
+				s.sweep(s.now())
+				s.mu.Unlock()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// StopSweeping stops dropping the expired simulations
+// - nothing happens if it is not sweeping
+func (s *Simulations) StopSweeping() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+// sweep drops the simulations that got no requests for longer than the TTL
+// - it is called with the lock held
+func (s *Simulations) sweep(now time.Time) {
+	for id, sim := range s.simulations {
+		if s.expired(sim, now) {
+			delete(s.simulations, id)
+		}
+	}
+}
+
+// expired returns true if a simulation got no requests for longer than the TTL
+func (s *Simulations) expired(sim *Simulation, now time.Time) bool {
+	return now.Sub(sim.lastUsed) > s.ttl
+}
+
+// newSimulationID returns a random ID, so a client can not guess the simulations of others
+func newSimulationID() (id string, err error) {
+	b := make([]byte, 16)
+	if _, err = rand.Read(b); err != nil {
+		return
+	}
+	id = hex.EncodeToString(b)
+	return
+}
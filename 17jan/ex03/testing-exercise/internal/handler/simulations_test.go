@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testdoubles/internal/hunter"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/prey"
+	"testdoubles/internal/simulator"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSimulationsRouter returns a router with the routes of the simulations, like the application
+func newSimulationsRouter(sims *Simulations) (rt *chi.Mux) {
+	rt = chi.NewRouter()
+	rt.Post("/simulations", sims.Create())
+	rt.Route("/simulations/{simulationId}", func(r chi.Router) {
+		r.Delete("/", sims.Delete())
+		r.Post("/configure-prey", sims.With(func(sim *Simulation) http.HandlerFunc { return sim.Hunter.ConfigurePrey }))
+		r.Post("/hunt", sims.With(func(sim *Simulation) http.HandlerFunc { return sim.Hunter.Hunt() }))
+	})
+	return
+}
+
+// newTestSimulation builds a simulation with a real white shark and tuna
+func newTestSimulation() (sim *Simulation, err error) {
+	sm := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+		MaxTimeToCatch: 100,
+		Positioner:     positioner.NewPositionerDefault(),
+	})
+	ht := hunter.NewWhiteShark(hunter.ConfigWhiteShark{Speed: 10, Position: &positioner.Position{}, Simulator: sm})
+	pr := prey.NewTuna(0, &positioner.Position{})
+	sim = &Simulation{Hunter: NewHunter(ht, pr, sm, nil), Batch: NewBatch(sm, nil)}
+	return
+}
+
+// serve serves a request on the router and returns the response
+func serve(rt http.Handler, method, target, body string) (res *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res = httptest.NewRecorder()
+	rt.ServeHTTP(res, req)
+	return
+}
+
+// create creates a simulation and returns its ID
+func create(t *testing.T, rt http.Handler) (id string) {
+	res := serve(rt, http.MethodPost, "/simulations", "")
+	var body ResponseBodySimulation
+	err := json.Unmarshal(res.Body.Bytes(), &body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, res.Code)
+	assert.Equal(t, "/simulations/"+body.ID, res.Header().Get("Location"))
+	id = body.ID
+	return
+}
+
+func TestSimulations_Create(t *testing.T) {
+	t.Run("success - each simulation has its own id", func(t *testing.T) {
+		// arrange
+		rt := newSimulationsRouter(NewSimulations(newTestSimulation, time.Minute, 0))
+
+		// act
+		id1 := create(t, rt)
+		id2 := create(t, rt)
+
+		// assert
+		assert.Len(t, id1, 32)
+		assert.NotEqual(t, id1, id2)
+	})
+
+	t.Run("failure - the simulation can not be built", func(t *testing.T) {
+		// arrange
+		factory := func() (*Simulation, error) { return nil, errors.New("no arena") }
+		rt := newSimulationsRouter(NewSimulations(factory, 0, 0))
+
+		// act
+		res := serve(rt, http.MethodPost, "/simulations", "")
+
+		// assert
+		expectedBody := `{"status": "Internal Server Error", "message": "Erro interno ao criar a simulação"}`
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+func TestSimulations_Create_Max(t *testing.T) {
+	t.Run("failure - no room for another simulation", func(t *testing.T) {
+		// arrange
+		rt := newSimulationsRouter(NewSimulations(newTestSimulation, time.Minute, 2))
+		create(t, rt)
+		create(t, rt)
+
+		// act
+		res := serve(rt, http.MethodPost, "/simulations", "")
+
+		// assert
+		expectedBody := `{"status": "Service Unavailable", "message": "Limite de simulações atingido, tente mais tarde"}`
+		assert.Equal(t, http.StatusServiceUnavailable, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("failure - no room, the simulation is not built", func(t *testing.T) {
+		// arrange
+		// - factory: counts the simulations it builds
+		built := 0
+		factory := func() (sim *Simulation, err error) {
+			built++
+			return newTestSimulation()
+		}
+		rt := newSimulationsRouter(NewSimulations(factory, time.Minute, 1))
+		create(t, rt)
+
+		// act
+		res := serve(rt, http.MethodPost, "/simulations", "")
+
+		// assert
+		assert.Equal(t, http.StatusServiceUnavailable, res.Code)
+		assert.Equal(t, 1, built)
+	})
+
+	t.Run("success - a simulation that can not be built gives its room back", func(t *testing.T) {
+		// arrange
+		// - factory: fails the first time
+		failed := false
+		factory := func() (sim *Simulation, err error) {
+			if !failed {
+				failed = true
+				err = errors.New("internal error")
+				return
+			}
+			return newTestSimulation()
+		}
+		rt := newSimulationsRouter(NewSimulations(factory, time.Minute, 1))
+		res := serve(rt, http.MethodPost, "/simulations", "")
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+
+		// act
+		res = serve(rt, http.MethodPost, "/simulations", "")
+
+		// assert
+		assert.Equal(t, http.StatusCreated, res.Code)
+	})
+
+	t.Run("success - the expired simulations make room", func(t *testing.T) {
+		// arrange
+		// - clock: moved by hand
+		now := time.Date(2024, 1, 17, 12, 0, 0, 0, time.UTC)
+		sims := NewSimulations(newTestSimulation, time.Minute, 1)
+		sims.now = func() time.Time { return now }
+		rt := newSimulationsRouter(sims)
+		create(t, rt)
+
+		// act
+		now = now.Add(2 * time.Minute)
+		id := create(t, rt)
+
+		// assert
+		assert.Len(t, sims.simulations, 1)
+		assert.Contains(t, sims.simulations, id)
+	})
+}
+
+func TestSimulations_With(t *testing.T) {
+	t.Run("success - simulations do not share the prey", func(t *testing.T) {
+		// arrange
+		rt := newSimulationsRouter(NewSimulations(newTestSimulation, time.Minute, 0))
+		id1 := create(t, rt)
+		id2 := create(t, rt)
+
+		// act
+		res1 := serve(rt, http.MethodPost, "/simulations/"+id1+"/configure-prey", `{"speed": 50, "position": {"X": 100}}`)
+		res2 := serve(rt, http.MethodPost, "/simulations/"+id2+"/configure-prey", `{"speed": 1, "position": {"X": 10}}`)
+		hunt1 := serve(rt, http.MethodPost, "/simulations/"+id1+"/hunt", "")
+		hunt2 := serve(rt, http.MethodPost, "/simulations/"+id2+"/hunt", "")
+
+		// assert
+		assert.Equal(t, http.StatusOK, res1.Code)
+		assert.Equal(t, http.StatusOK, res2.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, hunt1.Code)
+		assert.Contains(t, hunt1.Body.String(), `"prey":{"speed":50`)
+		assert.Equal(t, http.StatusOK, hunt2.Code)
+		assert.Contains(t, hunt2.Body.String(), `"prey":{"speed":1`)
+	})
+
+	t.Run("failure - simulation not found", func(t *testing.T) {
+		// arrange
+		rt := newSimulationsRouter(NewSimulations(newTestSimulation, time.Minute, 0))
+
+		// act
+		res := serve(rt, http.MethodPost, "/simulations/unknown/hunt", "")
+
+		// assert
+		expectedBody := `{"status": "Not Found", "message": "Simulação não encontrada"}`
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("ttl - idle simulations expire, used ones live on", func(t *testing.T) {
+		// arrange
+		// - clock: moved by hand
+		now := time.Date(2024, 1, 17, 12, 0, 0, 0, time.UTC)
+		sims := NewSimulations(newTestSimulation, time.Minute, 0)
+		sims.now = func() time.Time { return now }
+		rt := newSimulationsRouter(sims)
+		idle := create(t, rt)
+		used := create(t, rt)
+
+		// act
+		now = now.Add(40 * time.Second)
+		resUsed := serve(rt, http.MethodPost, "/simulations/"+used+"/hunt", "")
+		now = now.Add(40 * time.Second)
+		resIdle := serve(rt, http.MethodPost, "/simulations/"+idle+"/hunt", "")
+		resUsedAgain := serve(rt, http.MethodPost, "/simulations/"+used+"/hunt", "")
+
+		// assert
+		assert.Equal(t, http.StatusOK, resUsed.Code)
+		assert.Equal(t, http.StatusNotFound, resIdle.Code)
+		assert.Equal(t, http.StatusOK, resUsedAgain.Code)
+		assert.Len(t, sims.simulations, 1)
+	})
+}
+
+func TestSimulations_Delete(t *testing.T) {
+	// arrange
+	rt := newSimulationsRouter(NewSimulations(newTestSimulation, time.Minute, 0))
+	id := create(t, rt)
+
+	t.Run("success - simulation is deleted", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodDelete, "/simulations/"+id, "")
+		hunt := serve(rt, http.MethodPost, "/simulations/"+id+"/hunt", "")
+
+		// assert
+		assert.Equal(t, http.StatusNoContent, res.Code)
+		assert.Equal(t, http.StatusNotFound, hunt.Code)
+	})
+
+	t.Run("failure - simulation not found", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodDelete, "/simulations/"+id, "")
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
+
+func TestSimulations_StartSweeping(t *testing.T) {
+	// arrange
+	// - clock: every simulation is expired once it is created
+	sims := NewSimulations(newTestSimulation, time.Minute, 0)
+	rt := newSimulationsRouter(sims)
+	create(t, rt)
+	create(t, rt)
+	sims.mu.Lock()
+	sims.now = func() time.Time { return time.Now().Add(time.Hour) }
+	sims.mu.Unlock()
+
+	// act
+	sims.StartSweeping(time.Millisecond)
+	defer sims.StopSweeping()
+
+	// assert
+	assert.Eventually(t, func() bool {
+		sims.mu.Lock()
+		defer sims.mu.Unlock()
+		return len(sims.simulations) == 0
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,470 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"testdoubles/internal/hunter"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/prey"
+	"testdoubles/internal/simulator"
+	"testdoubles/internal/species"
+	"testdoubles/platform/web/request"
+	"testdoubles/platform/web/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	// ErrSubjectSpeedRequired is returned when the speed of a subject is not given
+	ErrSubjectSpeedRequired = errors.New("speed is required")
+	// ErrSubjectIDInvalid is returned when the id of a subject can not be part of a URL as it is
+	ErrSubjectIDInvalid = errors.New("id must have 1 to 64 letters, digits, '.', '_', '~' or '-'")
+	// ErrSubjectIDTaken is returned when a subject is added with the id of another one of the collection
+	ErrSubjectIDTaken = errors.New("id is taken")
+	// ErrSubjectsFull is returned when a subject is added to a collection with maxSubjects subjects
+	ErrSubjectsFull = errors.New("the collection is full")
+	// ErrSubjectNoProfile is returned when a subject added to a collection is not of a species
+	ErrSubjectNoProfile = errors.New("subject has no species profile")
+)
+
+// subjectIDPattern is what the id given to a subject looks like, so it is a path segment of a URL as it is
+var subjectIDPattern = regexp.MustCompile(`^[A-Za-z0-9._~-]{1,64}$`)
+
+// maxSubjects is the max number of subjects of a collection
+const maxSubjects = 1000
+
+// entity is a hunter or a prey of a collection of subjects
+type entity interface {
+	subject
+	Configure(speed float64, position *positioner.Position)
+	GetProfile() (profile species.Profile)
+}
+
+// NewHunters returns a new Subjects handler of hunters.
+// - the hunters are built from the profiles of the catalogue (nil for the default catalogue) and hunt with the simulator
+// - arena is where the hunters live (nil for the default arena)
+func NewHunters(sm simulator.CatchSimulator, arena *positioner.Arena, catalogue species.Catalogue) *Subjects {
+	s := newSubjects(arena, catalogue)
+	s.path = "/hunters"
+	s.defaultSpecies = species.WhiteShark
+	s.notFound = "Caçador não encontrado"
+	s.conflict = "Já existe um caçador com este id"
+	s.invalid = "Configuração do caçador inválida: "
+	s.full = "Limite de caçadores atingido"
+	s.build = func(profile species.Profile, position *positioner.Position) entity {
+		return hunter.NewSpecies(hunter.ConfigSpecies{Profile: profile, Position: position, Arena: s.arena, Simulator: sm})
+	}
+	return s
+}
+
+// NewPreys returns a new Subjects handler of preys.
+// - the preys are built from the profiles of the catalogue (nil for the default catalogue)
+// - arena is where the preys live (nil for the default arena)
+func NewPreys(arena *positioner.Arena, catalogue species.Catalogue) *Subjects {
+	s := newSubjects(arena, catalogue)
+	s.path = "/prey"
+	s.defaultSpecies = species.Tuna
+	s.notFound = "Presa não encontrada"
+	s.conflict = "Já existe uma presa com este id"
+	s.invalid = "Configuração da presa inválida: "
+	s.full = "Limite de presas atingido"
+	s.build = func(profile species.Profile, position *positioner.Position) entity {
+		return prey.NewSpecies(prey.ConfigSpecies{Profile: profile, Position: position, Arena: s.arena})
+	}
+	return s
+}
+
+// newSubjects returns an empty collection of subjects
+func newSubjects(arena *positioner.Arena, catalogue species.Catalogue) *Subjects {
+	// default config
+	if arena == nil {
+		arena = positioner.NewArenaDefault()
+	}
+	if catalogue == nil {
+		catalogue = species.Default
+	}
+	return &Subjects{arena: arena, catalogue: catalogue, entities: make(map[string]entity)}
+}
+
+// Subjects returns handlers to manage a collection of hunters or of preys as resources.
+type Subjects struct {
+	// arena is where the subjects live
+	arena *positioner.Arena
+	// catalogue has the profiles the subjects are built from
+	catalogue species.Catalogue
+	// build builds a subject of a species, at a position (nil to spawn it at random)
+	build func(profile species.Profile, position *positioner.Position) entity
+	// path is where the collection is served, for the Location headers
+	path string
+	// defaultSpecies is the species of a subject if none is given
+	defaultSpecies string
+	// messages of the errors
+	notFound string
+	conflict string
+	invalid  string
+	full     string
+
+	// entities are the subjects by ID
+	entities map[string]entity
+	// ids are the IDs of the subjects, in the order they were created
+	ids []string
+	// lastID is the last ID given to a subject
+	lastID int
+	// mu guards the subjects
+	mu sync.Mutex
+}
+
+// RequestBodySubject is an struct to create or edit a hunter or a prey in JSON format.
+// - on create: a missing id is given by the server, a missing speed is drawn from the species and a missing position is random
+// - on PUT: speed and position are required; on PATCH: the missing fields are kept
+type RequestBodySubject struct {
+	ID       string               `json:"id"`
+	Species  string               `json:"species"`
+	Speed    *float64             `json:"speed"`
+	Position *positioner.Position `json:"position"`
+}
+
+// SubjectResourceJSON is an struct that represents a hunter or a prey of a collection in JSON format.
+type SubjectResourceJSON struct {
+	ID      string `json:"id"`
+	Species string `json:"species"`
+	SubjectJSON
+}
+
+// Example
+// curl http://localhost:8080/hunters
+
+// List returns the subjects of the collection, in the order they were created.
+func (s *Subjects) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Println("call List")
+
+		// process
+		s.mu.Lock()
+		body := make([]SubjectResourceJSON, 0, len(s.ids))
+		for _, id := range s.ids {
+			body = append(body, resourceToJSON(id, s.entities[id]))
+		}
+		s.mu.Unlock()
+
+		// response
+		response.JSON(w, http.StatusOK, body)
+	}
+}
+
+// Example
+// curl -X POST http://localhost:8080/hunters \
+// -H "Content-Type: application/json" \
+// -d '{
+//   "id": "jaws",
+//   "species": "white-shark",
+//   "speed": 10.0,
+//   "position": {"X": 100.0, "Y": 0.0, "Z": 0.0}
+// }'
+
+// Create creates a subject and returns where it is.
+// - there is no room for it while the collection has maxSubjects subjects
+func (s *Subjects) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Println("call Create")
+
+		// request
+		var body RequestBodySubject
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+			return
+		}
+		if body.Species == "" {
+			body.Species = s.defaultSpecies
+		}
+		var profile species.Profile
+		if body.ID != "" && !subjectIDPattern.MatchString(body.ID) {
+			err = ErrSubjectIDInvalid
+		}
+		if err == nil {
+			profile, err = s.catalogue.Lookup(body.Species)
+		}
+		if err == nil {
+			err = s.validate(body.Speed, body.Position)
+		}
+		if err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, s.invalid+err.Error())
+			return
+		}
+
+		// process
+		e := s.build(profile, body.Position)
+		if body.Speed != nil {
+			e.Configure(*body.Speed, e.GetPosition())
+		}
+		s.mu.Lock()
+		if _, ok := s.entities[body.ID]; ok {
+			s.mu.Unlock()
+			response.Error(w, http.StatusConflict, s.conflict)
+			return
+		}
+		if len(s.entities) >= maxSubjects {
+			s.mu.Unlock()
+			response.Error(w, http.StatusServiceUnavailable, s.full)
+			return
+		}
+		id := body.ID
+		if id == "" {
+			id = s.nextID()
+		}
+		s.entities[id] = e
+		s.ids = append(s.ids, id)
+		s.mu.Unlock()
+
+		// response
+		w.Header().Set("Location", s.path+"/"+id)
+		response.JSON(w, http.StatusCreated, resourceToJSON(id, e))
+	}
+}
+
+// Example
+// curl http://localhost:8080/hunters/jaws
+
+// Get returns a subject by ID.
+func (s *Subjects) Get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Println("call Get")
+
+		// request
+		id := chi.URLParam(r, "id")
+
+		// process
+		e, ok := s.lookup(id)
+		if !ok {
+			response.Error(w, http.StatusNotFound, s.notFound)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, resourceToJSON(id, e))
+	}
+}
+
+// Example
+// curl -X PUT http://localhost:8080/hunters/jaws \
+// -H "Content-Type: application/json" \
+// -d '{"species": "orca", "speed": 12.0, "position": {"X": 0.0, "Y": 0.0, "Z": 10.0}}'
+
+// Replace replaces a subject by ID: a new one of the species, with the speed and the position.
+func (s *Subjects) Replace() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Println("call Replace")
+
+		// request
+		id := chi.URLParam(r, "id")
+		var body RequestBodySubject
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+			return
+		}
+		if body.ID != "" && body.ID != id {
+			response.Error(w, http.StatusBadRequest, "O id do corpo não é o da URL")
+			return
+		}
+		if body.Species == "" {
+			body.Species = s.defaultSpecies
+		}
+		profile, err := s.catalogue.Lookup(body.Species)
+		if err == nil && body.Speed == nil {
+			err = ErrSubjectSpeedRequired
+		}
+		if err == nil {
+			err = validateSubject(*body.Speed, body.Position, s.arena)
+		}
+		if err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, s.invalid+err.Error())
+			return
+		}
+
+		// process
+		s.mu.Lock()
+		if _, ok := s.entities[id]; !ok {
+			s.mu.Unlock()
+			response.Error(w, http.StatusNotFound, s.notFound)
+			return
+		}
+		e := s.build(profile, body.Position)
+		e.Configure(*body.Speed, body.Position)
+		s.entities[id] = e
+		s.mu.Unlock()
+
+		// response
+		response.JSON(w, http.StatusOK, resourceToJSON(id, e))
+	}
+}
+
+// Example
+// curl -X PATCH http://localhost:8080/hunters/jaws \
+// -H "Content-Type: application/json" \
+// -d '{"speed": 14.0}'
+
+// Update updates the given fields of a subject by ID: a new species keeps the speed and the position.
+func (s *Subjects) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Println("call Update")
+
+		// request
+		id := chi.URLParam(r, "id")
+		var body RequestBodySubject
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "Erro ao decodificar JSON: "+err.Error())
+			return
+		}
+		if body.ID != "" && body.ID != id {
+			response.Error(w, http.StatusBadRequest, "O id do corpo não é o da URL")
+			return
+		}
+		var profile species.Profile
+		if body.Species != "" {
+			profile, err = s.catalogue.Lookup(body.Species)
+		}
+		if err == nil {
+			err = s.validate(body.Speed, body.Position)
+		}
+		if err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, s.invalid+err.Error())
+			return
+		}
+
+		// process
+		// - under the lock, so 2 updates of the same subject do not mix their fields
+		s.mu.Lock()
+		e, ok := s.entities[id]
+		if !ok {
+			s.mu.Unlock()
+			response.Error(w, http.StatusNotFound, s.notFound)
+			return
+		}
+		speed, position := e.GetSpeed(), e.GetPosition()
+		if body.Speed != nil {
+			speed = *body.Speed
+		}
+		if body.Position != nil {
+			position = body.Position
+		}
+		if body.Species != "" {
+			e = s.build(profile, position)
+			s.entities[id] = e
+		}
+		e.Configure(speed, position)
+		s.mu.Unlock()
+
+		// response
+		response.JSON(w, http.StatusOK, resourceToJSON(id, e))
+	}
+}
+
+// Example
+// curl -X DELETE http://localhost:8080/hunters/jaws
+
+// Delete deletes a subject by ID.
+func (s *Subjects) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Println("call Delete")
+
+		// request
+		id := chi.URLParam(r, "id")
+
+		// process
+		s.mu.Lock()
+		_, ok := s.entities[id]
+		if ok {
+			delete(s.entities, id)
+			for i := range s.ids {
+				if s.ids[i] == id {
+					s.ids = append(s.ids[:i], s.ids[i+1:]...)
+					break
+				}
+			}
+		}
+		s.mu.Unlock()
+		if !ok {
+			response.Error(w, http.StatusNotFound, s.notFound)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusNoContent, nil)
+	}
+}
+
+// Add adds a subject to the collection, like Create does with the ones of the requests
+// - sub must be of a species, like the ones the collection builds (see hunter.NewSpecies and prey.NewSpecies)
+func (s *Subjects) Add(id string, sub subject) (err error) {
+	e, ok := sub.(entity)
+	if !ok {
+		err = ErrSubjectNoProfile
+		return
+	}
+	if !subjectIDPattern.MatchString(id) {
+		err = ErrSubjectIDInvalid
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entities[id]; ok {
+		err = ErrSubjectIDTaken
+		return
+	}
+	if len(s.entities) >= maxSubjects {
+		err = ErrSubjectsFull
+		return
+	}
+	s.entities[id] = e
+	s.ids = append(s.ids, id)
+	return
+}
+
+// lookup returns a subject by ID
+func (s *Subjects) lookup(id string) (e entity, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok = s.entities[id]
+	return
+}
+
+// validate validates the optional speed and position of a subject
+func (s *Subjects) validate(speed *float64, position *positioner.Position) (err error) {
+	if speed != nil && *speed < 0 {
+		err = ErrSubjectSpeedNegative
+		return
+	}
+	if position != nil {
+		err = s.arena.Validate(position)
+	}
+	return
+}
+
+// nextID returns the next free ID of the collection
+// - it is called with the lock held
+func (s *Subjects) nextID() (id string) {
+	for {
+		s.lastID++
+		id = strconv.Itoa(s.lastID)
+		if _, ok := s.entities[id]; !ok {
+			return
+		}
+	}
+}
+
+// resourceToJSON converts a subject of a collection to JSON format.
+func resourceToJSON(id string, e entity) (s SubjectResourceJSON) {
+	s.ID = id
+	s.Species = e.GetProfile().Name
+	s.SubjectJSON = stateToJSON(e)
+	return
+}
@@ -0,0 +1,347 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/prey"
+	"testdoubles/internal/simulator"
+	"testdoubles/internal/species"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSubjectsRouter returns a router with the routes of a collection of subjects, like the application
+func newSubjectsRouter(path string, s *Subjects) (rt *chi.Mux) {
+	rt = chi.NewRouter()
+	rt.Route(path, func(r chi.Router) {
+		r.Get("/", s.List())
+		r.Post("/", s.Create())
+		r.Get("/{id}", s.Get())
+		r.Put("/{id}", s.Replace())
+		r.Patch("/{id}", s.Update())
+		r.Delete("/{id}", s.Delete())
+	})
+	return
+}
+
+// newTestHunters returns a collection of hunters that hunt with the default simulator
+func newTestHunters() *Subjects {
+	sm := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+		MaxTimeToCatch: 100,
+		Positioner:     positioner.NewPositionerDefault(),
+	})
+	return NewHunters(sm, nil, nil)
+}
+
+func TestSubjects_Create(t *testing.T) {
+	t.Run("success - subject with an id", func(t *testing.T) {
+		// arrange
+		rt := newSubjectsRouter("/hunters", newTestHunters())
+
+		// act
+		res := serve(rt, http.MethodPost, "/hunters", `{"id": "jaws", "species": "orca", "speed": 12, "position": {"X": 1, "Y": 2, "Z": 3}}`)
+
+		// assert
+		expectedBody := `{"id": "jaws", "species": "orca", "speed": 12, "position": {"X": 1, "Y": 2, "Z": 3}}`
+		assert.Equal(t, http.StatusCreated, res.Code)
+		assert.Equal(t, "/hunters/jaws", res.Header().Get("Location"))
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("success - subject without id, speed or position", func(t *testing.T) {
+		// arrange
+		rt := newSubjectsRouter("/prey", NewPreys(nil, nil))
+
+		// act
+		res := serve(rt, http.MethodPost, "/prey", `{}`)
+
+		// assert
+		var body SubjectResourceJSON
+		err := json.Unmarshal(res.Body.Bytes(), &body)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, res.Code)
+		assert.Equal(t, "/prey/1", res.Header().Get("Location"))
+		assert.Equal(t, "tuna", body.Species)
+		assert.GreaterOrEqual(t, body.Speed, 15.0)
+		assert.NoError(t, positioner.NewArenaDefault().Validate(body.Position))
+	})
+
+	t.Run("failure - id already exists", func(t *testing.T) {
+		// arrange
+		rt := newSubjectsRouter("/hunters", newTestHunters())
+		serve(rt, http.MethodPost, "/hunters", `{"id": "jaws"}`)
+
+		// act
+		res := serve(rt, http.MethodPost, "/hunters", `{"id": "jaws"}`)
+
+		// assert
+		expectedBody := `{"status": "Conflict", "message": "Já existe um caçador com este id"}`
+		assert.Equal(t, http.StatusConflict, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("failure - invalid subject", func(t *testing.T) {
+		type testCase struct {
+			name         string
+			body         string
+			expectedBody string
+		}
+		cases := []testCase{
+			// case 1: unknown species
+			{name: "unknown species", body: `{"species": "kraken"}`, expectedBody: `{"status": "Unprocessable Entity", "message": "Configuração do caçador inválida: unknown species: kraken"}`},
+			// case 2: negative speed
+			{name: "negative speed", body: `{"speed": -1}`, expectedBody: `{"status": "Unprocessable Entity", "message": "Configuração do caçador inválida: speed can not be negative"}`},
+			// case 3: id with only whitespace
+			{name: "blank id", body: `{"id": "  "}`, expectedBody: `{"status": "Unprocessable Entity", "message": "Configuração do caçador inválida: id must have 1 to 64 letters, digits, '.', '_', '~' or '-'"}`},
+			// case 4: id that is not a path segment
+			{name: "id with a slash", body: `{"id": "jaws/1"}`, expectedBody: `{"status": "Unprocessable Entity", "message": "Configuração do caçador inválida: id must have 1 to 64 letters, digits, '.', '_', '~' or '-'"}`},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				// arrange
+				rt := newSubjectsRouter("/hunters", newTestHunters())
+
+				// act
+				res := serve(rt, http.MethodPost, "/hunters", c.body)
+
+				// assert
+				assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+				assert.JSONEq(t, c.expectedBody, res.Body.String())
+			})
+		}
+	})
+}
+
+func TestSubjects_Create_Max(t *testing.T) {
+	// arrange
+	rt := newSubjectsRouter("/prey", NewPreys(nil, nil))
+	for i := 0; i < maxSubjects; i++ {
+		serve(rt, http.MethodPost, "/prey", `{}`)
+	}
+
+	// act
+	res := serve(rt, http.MethodPost, "/prey", `{}`)
+
+	// assert
+	expectedBody := `{"status": "Service Unavailable", "message": "Limite de presas atingido"}`
+	assert.Equal(t, http.StatusServiceUnavailable, res.Code)
+	assert.JSONEq(t, expectedBody, res.Body.String())
+}
+
+func TestSubjects_List(t *testing.T) {
+	// arrange
+	rt := newSubjectsRouter("/hunters", newTestHunters())
+	serve(rt, http.MethodPost, "/hunters", `{"id": "b", "species": "orca", "speed": 10, "position": {"X": 1}}`)
+	serve(rt, http.MethodPost, "/hunters", `{"id": "a", "species": "dolphin", "speed": 8, "position": {"X": 2}}`)
+
+	// act
+	res := serve(rt, http.MethodGet, "/hunters", "")
+
+	// assert
+	expectedBody := `[
+		{"id": "b", "species": "orca", "speed": 10, "position": {"X": 1, "Y": 0, "Z": 0}},
+		{"id": "a", "species": "dolphin", "speed": 8, "position": {"X": 2, "Y": 0, "Z": 0}}
+	]`
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.JSONEq(t, expectedBody, res.Body.String())
+}
+
+func TestSubjects_Add(t *testing.T) {
+	// arrange
+	preys := NewPreys(nil, nil)
+	rt := newSubjectsRouter("/prey", preys)
+	newSardine := func() *prey.Species {
+		return prey.NewSpecies(prey.ConfigSpecies{Profile: species.Default[species.Sardine], Position: &positioner.Position{X: 5}})
+	}
+
+	t.Run("success - subject is served like the created ones", func(t *testing.T) {
+		// act
+		err := preys.Add("nemo", newSardine())
+
+		// assert
+		res := serve(rt, http.MethodGet, "/prey/nemo", "")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Contains(t, res.Body.String(), `"species":"sardine"`)
+	})
+
+	t.Run("failure - invalid subject", func(t *testing.T) {
+		type testCase struct {
+			name string
+			id   string
+			sub  subject
+			err  error
+		}
+		cases := []testCase{
+			// case 1: the id is taken
+			{name: "id is taken", id: "nemo", sub: newSardine(), err: ErrSubjectIDTaken},
+			// case 2: the id can not be part of a URL
+			{name: "id is invalid", id: "dory/1", sub: newSardine(), err: ErrSubjectIDInvalid},
+			// case 3: the subject is not of a species
+			{name: "subject without species", id: "dory", sub: prey.NewPreyStub(), err: ErrSubjectNoProfile},
+		}
+
+		// run tests
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				// act
+				err := preys.Add(c.id, c.sub)
+
+				// assert
+				assert.ErrorIs(t, err, c.err)
+			})
+		}
+	})
+}
+
+func TestSubjects_Get(t *testing.T) {
+	// arrange
+	rt := newSubjectsRouter("/prey", NewPreys(nil, nil))
+	serve(rt, http.MethodPost, "/prey", `{"id": "nemo", "species": "sardine", "speed": 2, "position": {"X": 5}}`)
+
+	t.Run("success - subject found", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodGet, "/prey/nemo", "")
+
+		// assert
+		expectedBody := `{"id": "nemo", "species": "sardine", "speed": 2, "position": {"X": 5, "Y": 0, "Z": 0}}`
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("failure - subject not found", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodGet, "/prey/dory", "")
+
+		// assert
+		expectedBody := `{"status": "Not Found", "message": "Presa não encontrada"}`
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+func TestSubjects_Replace(t *testing.T) {
+	// arrange
+	rt := newSubjectsRouter("/hunters", newTestHunters())
+	serve(rt, http.MethodPost, "/hunters", `{"id": "jaws", "speed": 10, "position": {"X": 1}}`)
+
+	t.Run("success - subject is replaced", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodPut, "/hunters/jaws", `{"species": "orca", "speed": 12, "position": {"Z": 3}}`)
+
+		// assert
+		expectedBody := `{"id": "jaws", "species": "orca", "speed": 12, "position": {"X": 0, "Y": 0, "Z": 3}}`
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("failure - speed is required", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodPut, "/hunters/jaws", `{"position": {"Z": 3}}`)
+
+		// assert
+		expectedBody := `{"status": "Unprocessable Entity", "message": "Configuração do caçador inválida: speed is required"}`
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("failure - id of the body is not the one of the url", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodPut, "/hunters/jaws", `{"id": "bruce", "speed": 1, "position": {"Z": 3}}`)
+
+		// assert
+		expectedBody := `{"status": "Bad Request", "message": "O id do corpo não é o da URL"}`
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("failure - subject not found", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodPut, "/hunters/bruce", `{"speed": 1, "position": {"Z": 3}}`)
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
+
+func TestSubjects_Update(t *testing.T) {
+	// arrange
+	rt := newSubjectsRouter("/hunters", newTestHunters())
+	serve(rt, http.MethodPost, "/hunters", `{"id": "jaws", "speed": 10, "position": {"X": 1}}`)
+
+	t.Run("success - only the given fields change", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodPatch, "/hunters/jaws", `{"speed": 14}`)
+
+		// assert
+		expectedBody := `{"id": "jaws", "species": "white-shark", "speed": 14, "position": {"X": 1, "Y": 0, "Z": 0}}`
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("success - a new species keeps the speed and the position", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodPatch, "/hunters/jaws", `{"species": "mako-shark"}`)
+
+		// assert
+		expectedBody := `{"id": "jaws", "species": "mako-shark", "speed": 14, "position": {"X": 1, "Y": 0, "Z": 0}}`
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("failure - id of the body is not the one of the url", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodPatch, "/hunters/jaws", `{"id": "bruce"}`)
+
+		// assert
+		expectedBody := `{"status": "Bad Request", "message": "O id do corpo não é o da URL"}`
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("failure - position out of the arena", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodPatch, "/hunters/jaws", `{"position": {"X": -1}}`)
+
+		// assert
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+	})
+
+	t.Run("failure - subject not found", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodPatch, "/hunters/bruce", `{"speed": 1}`)
+
+		// assert
+		expectedBody := `{"status": "Not Found", "message": "Caçador não encontrado"}`
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+func TestSubjects_Delete(t *testing.T) {
+	// arrange
+	rt := newSubjectsRouter("/hunters", newTestHunters())
+	serve(rt, http.MethodPost, "/hunters", `{"id": "jaws"}`)
+
+	t.Run("success - subject is deleted", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodDelete, "/hunters/jaws", "")
+		list := serve(rt, http.MethodGet, "/hunters", "")
+
+		// assert
+		assert.Equal(t, http.StatusNoContent, res.Code)
+		assert.JSONEq(t, `[]`, list.Body.String())
+	})
+
+	t.Run("failure - subject not found", func(t *testing.T) {
+		// act
+		res := serve(rt, http.MethodDelete, "/hunters/jaws", "")
+
+		// assert
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
@@ -2,19 +2,107 @@ package hunter
 
 import (
 	"errors"
+	"fmt"
 	"testdoubles/internal/positioner"
 	"testdoubles/internal/prey"
+	"testdoubles/internal/simulator"
 )
 
 var (
 	// ErrCanNotHunt is returned when the hunter can not hunt the prey
 	ErrCanNotHunt = errors.New("can not hunt the prey")
+	// ErrNoResult is the reason of a failed hunt when the simulator does not say how it went
+	ErrNoResult = errors.New("the simulator gave no result")
 )
 
+// HuntError is the error returned when the hunter can not hunt the prey
+// It matches ErrCanNotHunt and wraps the reason, e.g. simulator.ErrPreyFaster
+type HuntError struct {
+	// Reason is why the hunter can not hunt the prey
+	Reason error
+}
+
+// Error returns the message of the error
+func (e *HuntError) Error() string {
+	return ErrCanNotHunt.Error() + ": " + e.Reason.Error()
+}
+
+// Is returns true if the target is ErrCanNotHunt
+func (e *HuntError) Is(target error) bool {
+	return target == ErrCanNotHunt
+}
+
+// Unwrap returns the reason of the error
+func (e *HuntError) Unwrap() error {
+	return e.Reason
+}
+
 // Hunter is an interface that represents a hunter
 type Hunter interface {
 	// Hunt hunts the prey
-	Hunt(prey prey.Prey) (duration float64, err error)
+	// - result: is how the hunt went, also returned when the hunter can not hunt the prey
+	Hunt(prey prey.Prey) (result *simulator.CatchResult, err error)
 	// Configure configures the hunter
 	Configure(speed float64, position *positioner.Position)
-}
\ No newline at end of file
+	// GetSpeed returns the speed of the hunter
+	GetSpeed() (speed float64)
+	// GetPosition returns the position of the hunter
+	GetPosition() (position *positioner.Position)
+}
+
+// Reacher is an interface that represents a hunter that catches the prey from a distance of its own
+type Reacher interface {
+	// GetCaptureRadius returns how close the hunter gets to a prey to catch it (in meters, zero for the default)
+	GetCaptureRadius() (radius float64)
+}
+
+// hunt checks if a hunter can catch the prey with the simulator
+// - the prey evades, gets tired and drifts if it can
+// - name is how the hunter is called in the error
+func hunt(sm simulator.CatchSimulator, self *simulator.Subject, pr prey.Prey, name string) (result *simulator.CatchResult, err error) {
+	// get the state of the prey
+	preySubject := snapshot(pr)
+	// - the prey may evade the hunter
+	if ev, ok := pr.(prey.Evader); ok {
+		preySubject.Navigator = ev
+	}
+
+	result, ok := sm.CanCatch(self, preySubject)
+	// - a simulator may give no result: the hunt is empty, and failed unless the simulator says otherwise
+	reason := ErrNoResult
+	if result == nil {
+		result = &simulator.CatchResult{}
+	} else {
+		reason = result.Outcome.Err()
+	}
+	// - the states the hunt started from: the ones read once, so they can be told along with how it went
+	result.HunterStart, result.PreyStart = self, preySubject
+	if !ok {
+		err = &HuntError{Reason: fmt.Errorf("%s can not catch the prey: %w", name, reason)}
+		return
+	}
+	return
+}
+
+// snapshot returns a copy of the state of the prey
+// - read at once if the prey can, so a concurrent Configure does not tear it
+func snapshot(pr prey.Prey) (subject *simulator.Subject) {
+	if sn, ok := pr.(simulator.Snapshotter); ok {
+		subject = sn.Snapshot()
+		return
+	}
+
+	subject = &simulator.Subject{
+		Position: pr.GetPosition(),
+		Speed:    pr.GetSpeed(),
+	}
+	// - the prey may speed up and get tired
+	if sp, ok := pr.(prey.Sprinter); ok {
+		subject.Kinematics = sp.GetKinematics()
+	}
+	// - the current may carry the prey along
+	if dr, ok := pr.(prey.Drifter); ok {
+		subject.Drag = dr.GetDrag()
+	}
+	return
+}
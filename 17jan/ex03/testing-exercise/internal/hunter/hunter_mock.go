@@ -3,32 +3,39 @@ package hunter
 import (
 	"testdoubles/internal/positioner"
 	"testdoubles/internal/prey"
+	"testdoubles/internal/simulator"
 )
 
 // NewHunter return a mock implementation of Hunter
 func NewHunterMock() *HunterMock {
 	return &HunterMock{
-		HuntFunc: func(pr prey.Prey) (duration float64, err error) {return},
+		HuntFunc: func(pr prey.Prey) (result *simulator.CatchResult, err error) {return},
 		ConfigureFunc: func(speed float64, position *positioner.Position) {},
+		GetSpeedFunc: func() (speed float64) {return},
+		GetPositionFunc: func() (position *positioner.Position) {return},
 	}
 }
 
 // Hunter is a mock implementation of Hunter
 type HunterMock struct {
-	HuntFunc func(pr prey.Prey) (duration float64, err error)
+	HuntFunc func(pr prey.Prey) (result *simulator.CatchResult, err error)
 	ConfigureFunc func(speed float64, position *positioner.Position)
+	GetSpeedFunc func() (speed float64)
+	GetPositionFunc func() (position *positioner.Position)
 	// observers
 	Calls struct {
 		Hunt int
 		Configure int
+		GetSpeed int
+		GetPosition int
 	}
 }
 
-func (ht *HunterMock) Hunt(pr prey.Prey) (duration float64, err error) {
+func (ht *HunterMock) Hunt(pr prey.Prey) (result *simulator.CatchResult, err error) {
 	// observers
 	ht.Calls.Hunt++
 
-	duration, err = ht.HuntFunc(pr)
+	result, err = ht.HuntFunc(pr)
 	return
 }
 
@@ -37,4 +44,20 @@ func (ht *HunterMock) Configure(speed float64, position *positioner.Position) {
 	ht.Calls.Configure++
 
 	ht.ConfigureFunc(speed, position)
+}
+
+func (ht *HunterMock) GetSpeed() (speed float64) {
+	// observers
+	ht.Calls.GetSpeed++
+
+	speed = ht.GetSpeedFunc()
+	return
+}
+
+func (ht *HunterMock) GetPosition() (position *positioner.Position) {
+	// observers
+	ht.Calls.GetPosition++
+
+	position = ht.GetPositionFunc()
+	return
 }
\ No newline at end of file
@@ -0,0 +1,175 @@
+package hunter
+
+import (
+	"math/rand"
+	"sync"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/prey"
+	"testdoubles/internal/simulator"
+	"testdoubles/internal/species"
+)
+
+// ConfigSpecies is the configuration for Species
+type ConfigSpecies struct {
+	// Profile is what the hunter is like: speed, kinematics, size and depth
+	Profile species.Profile
+	// Position of the hunter (nil to spawn it at random in the arena, at the depth of the species)
+	Position *positioner.Position
+	// Arena where the hunter spawns (nil for the default arena)
+	Arena *positioner.Arena
+	// Src is the source of the random numbers of the speed and the position (nil for the global source of math/rand)
+	Src rand.Source
+	// Simulator checks if the hunter can catch a prey
+	Simulator simulator.CatchSimulator
+	// Strategy is how the hunter chases the prey (nil to let the simulator decide)
+	Strategy PursuitStrategy
+	// Perception is how the hunter senses the prey (nil to always know where it is)
+	Perception *simulator.Perception
+	// Drag is how much the current carries the hunter, from 0 (not at all) to 1 (like the water)
+	Drag float64
+}
+
+// NewSpecies creates a new Species
+// - the top speed of the hunter is drawn from the profile, then its position if it has none
+func NewSpecies(config ConfigSpecies) (h *Species) {
+	var rd *rand.Rand
+	if config.Src != nil {
+		rd = rand.New(config.Src)
+	}
+
+	speed := config.Profile.Speed.Sample(rd)
+	position := config.Position.Clone()
+	if position == nil {
+		position = config.Profile.Spawn(config.Arena, rd)
+	}
+
+	h = &Species{
+		profile:    config.Profile,
+		speed:      speed,
+		position:   position,
+		simulator:  config.Simulator,
+		strategy:   config.Strategy,
+		kinematics: config.Profile.Kinematics(speed),
+		perception: config.Perception,
+		drag:       config.Drag,
+	}
+	return
+}
+
+// Species is an implementation of the Hunter interface built from the profile of a species
+// A new species of hunter is a new profile in a catalogue, not a new type
+type Species struct {
+	// profile of the species
+	profile species.Profile
+	// speed in m/s
+	speed float64
+	// position of the hunter in the arena
+	position *positioner.Position
+	// simulator
+	simulator simulator.CatchSimulator
+	// strategy used to chase the prey
+	strategy PursuitStrategy
+	// kinematics: acceleration and stamina of the species
+	kinematics simulator.Kinematics
+	// perception: how the hunter senses the prey
+	perception *simulator.Perception
+	// drag: how much the current carries the hunter
+	drag float64
+	// mu guards the speed, the position and the cruise speed, so the hunter can be configured while it hunts
+	mu sync.RWMutex
+}
+
+// Hunt hunts the prey
+func (s *Species) Hunt(pr prey.Prey) (result *simulator.CatchResult, err error) {
+	// get the state of the hunter (a copy, so a concurrent Configure does not change the hunt)
+	self := s.Snapshot()
+	if s.strategy != nil {
+		self.Navigator = s.strategy
+	}
+
+	result, err = hunt(s.simulator, self, pr, s.profile.Name)
+	return
+}
+
+// Configure configures the hunter
+// - the cruise speed follows the new speed
+// - the hunter keeps a copy of the position
+func (s *Species) Configure(speed float64, position *positioner.Position) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.speed = speed
+	s.position = position.Clone()
+	s.kinematics.CruiseSpeed = s.profile.Cruise * speed
+}
+
+// GetSpeed returns the speed of the hunter
+func (s *Species) GetSpeed() (speed float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	speed = s.speed
+	return
+}
+
+// GetPosition returns a copy of the position of the hunter
+func (s *Species) GetPosition() (position *positioner.Position) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	position = s.position.Clone()
+	return
+}
+
+// GetKinematics returns the acceleration, top speed and stamina of the hunter
+func (s *Species) GetKinematics() (kinematics simulator.Kinematics) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	kinematics = s.kinematics
+	return
+}
+
+// Snapshot returns a copy of the state of the hunter, read at once
+func (s *Species) Snapshot() (subject *simulator.Subject) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	subject = &simulator.Subject{
+		Position:      s.position.Clone(),
+		Speed:         s.speed,
+		Kinematics:    s.kinematics,
+		Perception:    s.perception,
+		Drag:          s.drag,
+		CaptureRadius: s.profile.CaptureRadius,
+	}
+	return
+}
+
+// GetDrag returns how much the current carries the hunter
+func (s *Species) GetDrag() (drag float64) {
+	drag = s.drag
+	return
+}
+
+// GetCaptureRadius returns how close the hunter gets to a prey to catch it
+func (s *Species) GetCaptureRadius() (radius float64) {
+	radius = s.profile.CaptureRadius
+	return
+}
+
+// GetProfile returns the profile of the species of the hunter
+func (s *Species) GetProfile() (profile species.Profile) {
+	profile = s.profile
+	return
+}
+
+// Heading returns the direction the hunter heads to when it chases a prey
+// - it is nil if the hunter has no strategy, so the simulator decides
+func (s *Species) Heading(self, pr *simulator.Subject) (heading *positioner.Position) {
+	if s.strategy == nil {
+		return
+	}
+	heading = s.strategy.Heading(self, pr)
+	return
+}
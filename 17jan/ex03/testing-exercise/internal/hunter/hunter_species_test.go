@@ -0,0 +1,111 @@
+package hunter_test
+
+import (
+	"math/rand"
+	"testdoubles/internal/hunter"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/prey"
+	"testdoubles/internal/simulator"
+	"testdoubles/internal/species"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for the Species implementation of the Hunter interface
+func TestHunterSpecies_NewSpecies(t *testing.T) {
+	t.Run("built from the profile", func(t *testing.T) {
+		// arrange
+		profile := species.Default[species.Orca]
+
+		// act
+		impl := hunter.NewSpecies(hunter.ConfigSpecies{Profile: profile, Src: rand.NewSource(1)})
+
+		// assert
+		require.GreaterOrEqual(t, impl.GetSpeed(), profile.Speed.Min)
+		require.LessOrEqual(t, impl.GetSpeed(), profile.Speed.Max)
+		require.Equal(t, profile.Kinematics(impl.GetSpeed()), impl.GetKinematics())
+		require.Equal(t, profile.CaptureRadius, impl.GetCaptureRadius())
+		require.Equal(t, profile, impl.GetProfile())
+		// - at the depth of the species, down from the top of the default arena
+		require.GreaterOrEqual(t, impl.GetPosition().Z, 500-profile.Depth.Max)
+	})
+
+	t.Run("same seed, same hunter", func(t *testing.T) {
+		// act
+		h1 := hunter.NewSpecies(hunter.ConfigSpecies{Profile: species.Default[species.MakoShark], Src: rand.NewSource(42)})
+		h2 := hunter.NewSpecies(hunter.ConfigSpecies{Profile: species.Default[species.MakoShark], Src: rand.NewSource(42)})
+
+		// assert
+		require.Equal(t, h1.GetSpeed(), h2.GetSpeed())
+		require.Equal(t, h1.GetPosition(), h2.GetPosition())
+	})
+
+	t.Run("with a position", func(t *testing.T) {
+		// act
+		impl := hunter.NewSpecies(hunter.ConfigSpecies{Profile: species.Default[species.Barracuda], Position: &positioner.Position{X: 1, Y: 2, Z: 3}})
+
+		// assert
+		require.Equal(t, &positioner.Position{X: 1, Y: 2, Z: 3}, impl.GetPosition())
+	})
+}
+
+func TestHunterSpecies_Hunt(t *testing.T) {
+	t.Run("the subject of the hunter has the kinematics of the species", func(t *testing.T) {
+		// arrange
+		// - prey: stub
+		pr := prey.NewPreyStub()
+		pr.GetPositionFunc = func() (position *positioner.Position) {
+			return &positioner.Position{X: 10}
+		}
+		pr.GetSpeedFunc = func() (speed float64) {
+			return 3
+		}
+		// - simulator: mock
+		var got *simulator.Subject
+		sm := simulator.NewCatchSimulatorMock()
+		sm.CanCatchFunc = func(hunter, prey *simulator.Subject) (result *simulator.CatchResult, ok bool) {
+			got = hunter
+			return &simulator.CatchResult{Outcome: simulator.OutcomeCaught, Duration: 2}, true
+		}
+		// - hunter: dolphin
+		impl := hunter.NewSpecies(hunter.ConfigSpecies{Profile: species.Default[species.Dolphin], Position: &positioner.Position{}, Simulator: sm})
+		impl.Configure(10, &positioner.Position{})
+
+		// act
+		result, err := impl.Hunt(pr)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 2.0, result.Duration)
+		require.Equal(t, 10.0, got.Speed)
+		require.Equal(t, simulator.Kinematics{Acceleration: 4, CruiseSpeed: 4, Stamina: 120, MaxStamina: 120}, got.Kinematics)
+		require.Equal(t, species.Default[species.Dolphin].CaptureRadius, got.CaptureRadius)
+		// - the result tells the state the hunt started from: the one given to the simulator
+		require.Same(t, got, result.HunterStart)
+	})
+
+	t.Run("the hunter can not catch the prey - error names the species", func(t *testing.T) {
+		// arrange
+		pr := prey.NewPreyStub()
+		pr.GetPositionFunc = func() (position *positioner.Position) {
+			return &positioner.Position{X: 10}
+		}
+		pr.GetSpeedFunc = func() (speed float64) {
+			return 30
+		}
+		sm := simulator.NewCatchSimulatorMock()
+		sm.CanCatchFunc = func(hunter, prey *simulator.Subject) (result *simulator.CatchResult, ok bool) {
+			return &simulator.CatchResult{Outcome: simulator.OutcomePreyFaster}, false
+		}
+		impl := hunter.NewSpecies(hunter.ConfigSpecies{Profile: species.Default[species.Orca], Position: &positioner.Position{}, Simulator: sm})
+
+		// act
+		_, err := impl.Hunt(pr)
+
+		// assert
+		require.ErrorIs(t, err, hunter.ErrCanNotHunt)
+		require.ErrorIs(t, err, simulator.ErrPreyFaster)
+		require.Contains(t, err.Error(), "orca can not catch the prey")
+	})
+}
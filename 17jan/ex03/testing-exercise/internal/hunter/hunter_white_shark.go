@@ -1,28 +1,35 @@
 package hunter
 
 import (
-	"fmt"
 	"math/rand"
+	"sync"
 	"testdoubles/internal/positioner"
 	"testdoubles/internal/prey"
 	"testdoubles/internal/simulator"
-	"time"
+	"testdoubles/internal/species"
 )
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
-}
+// CreateWhiteShark creates a new WhiteShark (with random parameters)
+// - src is the source of the random numbers, so the same source replays the same shark
+// - if src is nil, the global source of math/rand is used
+func CreateWhiteShark(simulator simulator.CatchSimulator, src rand.Source) (h Hunter) {
+	var rd *rand.Rand
+	random := rand.Float64
+	if src != nil {
+		rd = rand.New(src)
+		random = rd.Float64
+	}
 
-// CreateWhiteShark creates a new WhiteShark (with default parameters)
-func CreateWhiteShark(simulator simulator.CatchSimulator) (h Hunter) {
 	// default config
-	// -> speed: 144 m/s
-	speed := rand.Float64() * 144.0 + 15.0
-	// -> position: random
+	// -> speed: the one of the white shark of the catalogue
+	speed := species.Default[species.WhiteShark].Speed.Sample(rd)
+	// -> position: random in the default arena (at any depth, so the same seed keeps replaying the same shark)
+	arena := positioner.NewArenaDefault()
+	min, max := arena.Min(), arena.Max()
 	position := &positioner.Position{
-		X: rand.Float64() * 500,
-		Y: rand.Float64() * 500,
-		Z: rand.Float64() * 500,
+		X: random()*(max.X-min.X) + min.X,
+		Y: random()*(max.Y-min.Y) + min.Y,
+		Z: random()*(max.Z-min.Z) + min.Z,
 	}
 
 	h = &WhiteShark{
@@ -38,14 +45,38 @@ type ConfigWhiteShark struct {
 	Speed float64
 	Position *positioner.Position
 	Simulator simulator.CatchSimulator
+	// Strategy is how the shark chases the prey (nil to let the simulator decide)
+	Strategy PursuitStrategy
+	// Acceleration of the shark in m/s² (zero to reach its speed instantly)
+	Acceleration float64
+	// MaxSpeed is the top speed of the shark in m/s (zero to use Speed as the top speed)
+	MaxSpeed float64
+	// CruiseSpeed is the speed in m/s the shark can hold forever (zero to never get tired)
+	CruiseSpeed float64
+	// Stamina is how long in seconds the shark can sprint above its cruise speed
+	Stamina float64
+	// Perception is how the shark senses the prey (nil to always know where it is)
+	Perception *simulator.Perception
+	// Drag is how much the current carries the shark, from 0 (not at all) to 1 (like the water)
+	Drag float64
 }
 
 // NewWhiteShark creates a new WhiteShark
 func NewWhiteShark(config ConfigWhiteShark) (h Hunter) {
 	h = &WhiteShark{
 		speed:     config.Speed,
-		position:  config.Position,
+		position:  config.Position.Clone(),
 		simulator: config.Simulator,
+		strategy:  config.Strategy,
+		kinematics: simulator.Kinematics{
+			Acceleration: config.Acceleration,
+			MaxSpeed:     config.MaxSpeed,
+			CruiseSpeed:  config.CruiseSpeed,
+			Stamina:      config.Stamina,
+			MaxStamina:   config.Stamina,
+		},
+		perception: config.Perception,
+		drag:       config.Drag,
 	}
 	return
 }
@@ -54,38 +85,96 @@ func NewWhiteShark(config ConfigWhiteShark) (h Hunter) {
 type WhiteShark struct {
 	// speed in m/s
 	speed float64
-	// position of the shark in the map of 500 * 500 meters
+	// position of the shark in the arena
 	position *positioner.Position
 	// simulator
 	simulator simulator.CatchSimulator
+	// strategy used to chase the prey
+	strategy PursuitStrategy
+	// kinematics: acceleration, top speed and stamina of the shark
+	kinematics simulator.Kinematics
+	// perception: how the shark senses the prey
+	perception *simulator.Perception
+	// drag: how much the current carries the shark
+	drag float64
+	// mu guards the speed and the position, so the shark can be configured while it hunts
+	mu sync.RWMutex
 }
 
 // Hunt hunts the prey
-func (w *WhiteShark) Hunt(prey prey.Prey) (duration float64, err error) {
-	// get the position of the prey
-	preySubject := &simulator.Subject{
-		Position: prey.GetPosition(),
-		Speed:    prey.GetSpeed(),
-	}
-
-	// get the position of the shark
-	sharkSubject := &simulator.Subject{
-		Position: w.position,
-		Speed:    w.speed,
+func (w *WhiteShark) Hunt(pr prey.Prey) (result *simulator.CatchResult, err error) {
+	// get the state of the shark (a copy, so a concurrent Configure does not change the hunt)
+	sharkSubject := w.Snapshot()
+	if w.strategy != nil {
+		sharkSubject.Navigator = w.strategy
 	}
 	
 	// check if shark can catch the prey
-	duration, ok := w.simulator.CanCatch(sharkSubject, preySubject)
-	if !ok {
-		err = fmt.Errorf("%w: shark can not catch the prey", ErrCanNotHunt)
-		return
-	}
-
+	result, err = hunt(w.simulator, sharkSubject, pr, "shark")
 	return
 }
 
 // Configure configures the shark
+// - the shark keeps a copy of the position
 func (w *WhiteShark) Configure(speed float64, position *positioner.Position) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
 	(*w).speed = speed
-	(*w).position = position
-}
\ No newline at end of file
+	(*w).position = position.Clone()
+}
+
+// GetSpeed returns the speed of the shark
+func (w *WhiteShark) GetSpeed() (speed float64) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	speed = w.speed
+	return
+}
+
+// GetPosition returns a copy of the position of the shark
+func (w *WhiteShark) GetPosition() (position *positioner.Position) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	position = w.position.Clone()
+	return
+}
+
+// Snapshot returns a copy of the state of the shark, read at once
+func (w *WhiteShark) Snapshot() (subject *simulator.Subject) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	subject = &simulator.Subject{
+		Position:   w.position.Clone(),
+		Speed:      w.speed,
+		Kinematics: w.kinematics,
+		Perception: w.perception,
+		Drag:       w.drag,
+	}
+	return
+}
+
+// GetKinematics returns the acceleration, top speed and stamina of the shark
+func (w *WhiteShark) GetKinematics() (kinematics simulator.Kinematics) {
+	kinematics = w.kinematics
+	return
+}
+
+// GetDrag returns how much the current carries the shark
+func (w *WhiteShark) GetDrag() (drag float64) {
+	drag = w.drag
+	return
+}
+
+// Heading returns the direction the shark heads to when it chases a prey
+// - it is nil if the shark has no strategy, so the simulator decides
+func (w *WhiteShark) Heading(shark, prey *simulator.Subject) (heading *positioner.Position) {
+	if w.strategy == nil {
+		return
+	}
+	heading = w.strategy.Heading(shark, prey)
+	return
+}
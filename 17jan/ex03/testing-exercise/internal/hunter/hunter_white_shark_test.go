@@ -1,6 +1,7 @@
 package hunter_test
 
 import (
+	"math/rand"
 	"testdoubles/internal/hunter"
 	"testdoubles/internal/positioner"
 	"testdoubles/internal/prey"
@@ -24,8 +25,8 @@ func TestHunterWhiteShark_Hunt(t *testing.T) {
 		}
 		// - simulator: mock
 		sm := simulator.NewCatchSimulatorMock()
-		sm.CanCatchFunc = func(hunter, prey *simulator.Subject) (duration float64, ok bool) {
-			return 20.0, true
+		sm.CanCatchFunc = func(hunter, prey *simulator.Subject) (result *simulator.CatchResult, ok bool) {
+			return &simulator.CatchResult{Outcome: simulator.OutcomeCaught, Duration: 20.0}, true
 		}
 		// - hunter: white shark
 		impl := hunter.NewWhiteShark(hunter.ConfigWhiteShark{
@@ -35,13 +36,13 @@ func TestHunterWhiteShark_Hunt(t *testing.T) {
 		})
 
 		// act
-		duration, err := impl.Hunt(pr)
+		result, err := impl.Hunt(pr)
 
 		// assert
 		expectedDuration := 20.0
 		expectedMockCallCanCatch := 1
 		require.NoError(t, err)
-		require.Equal(t, expectedDuration, duration)
+		require.Equal(t, expectedDuration, result.Duration)
 		require.Equal(t, expectedMockCallCanCatch, sm.Calls.CanCatch)
 	})
 
@@ -57,8 +58,8 @@ func TestHunterWhiteShark_Hunt(t *testing.T) {
 		}
 		// - simulator: mock
 		sm := simulator.NewCatchSimulatorMock()
-		sm.CanCatchFunc = func(hunter, prey *simulator.Subject) (duration float64, ok bool) {
-			return 0.0, false
+		sm.CanCatchFunc = func(hunter, prey *simulator.Subject) (result *simulator.CatchResult, ok bool) {
+			return &simulator.CatchResult{Outcome: simulator.OutcomePreyFaster}, false
 		}
 		// - hunter: white shark
 		impl := hunter.NewWhiteShark(hunter.ConfigWhiteShark{
@@ -68,15 +69,17 @@ func TestHunterWhiteShark_Hunt(t *testing.T) {
 		})
 
 		// act
-		duration, err := impl.Hunt(pr)
+		result, err := impl.Hunt(pr)
 
 		// assert
-		expectedErr := hunter.ErrCanNotHunt; expectedErrMsg := "can not hunt the prey: shark can not catch the prey"
+		expectedErr := hunter.ErrCanNotHunt; expectedErrMsg := "can not hunt the prey: shark can not catch the prey: prey is faster than the hunter"
+		expectedReason := simulator.ErrPreyFaster
 		expectedDuration := 0.0
 		expectedMockCallCanCatch := 1
 		require.ErrorIs(t, err, expectedErr)
+		require.ErrorIs(t, err, expectedReason)
 		require.EqualError(t, err, expectedErrMsg)
-		require.Equal(t, expectedDuration, duration)
+		require.Equal(t, expectedDuration, result.Duration)
 		require.Equal(t, expectedMockCallCanCatch, sm.Calls.CanCatch)
 	})
 
@@ -92,8 +95,8 @@ func TestHunterWhiteShark_Hunt(t *testing.T) {
 		}
 		// - simulator: mock
 		sm := simulator.NewCatchSimulatorMock()
-		sm.CanCatchFunc = func(hunter, prey *simulator.Subject) (duration float64, ok bool) {
-			return 0.0, false
+		sm.CanCatchFunc = func(hunter, prey *simulator.Subject) (result *simulator.CatchResult, ok bool) {
+			return &simulator.CatchResult{Outcome: simulator.OutcomeOutOfRange}, false
 		}
 		// - hunter: white shark
 		impl := hunter.NewWhiteShark(hunter.ConfigWhiteShark{
@@ -103,17 +106,56 @@ func TestHunterWhiteShark_Hunt(t *testing.T) {
 		})
 
 		// act
-		duration, err := impl.Hunt(pr)
+		result, err := impl.Hunt(pr)
 
 		// assert
-		expectedErr := hunter.ErrCanNotHunt; expErrMsg := "can not hunt the prey: shark can not catch the prey"
+		expectedErr := hunter.ErrCanNotHunt; expErrMsg := "can not hunt the prey: shark can not catch the prey: prey is out of range"
+		expectedReason := simulator.ErrOutOfRange
 		expectedDuration := 0.0
 		expectedMockCallCanCatch := 1
 		require.ErrorIs(t, err, expectedErr)
+		require.ErrorIs(t, err, expectedReason)
 		require.EqualError(t, err, expErrMsg)
-		require.Equal(t, expectedDuration, duration)
+		require.Equal(t, expectedDuration, result.Duration)
 		require.Equal(t, expectedMockCallCanCatch, sm.Calls.CanCatch)
 	})
+
+	t.Run("white shark can not hunt a prey - simulator gives no result", func(t *testing.T) {
+		// arrange
+		// - prey: stub
+		pr := prey.NewPreyStub()
+		pr.GetPositionFunc = func() (position *positioner.Position) {
+			return &positioner.Position{X: 0, Y: 0, Z: 0}
+		}
+		pr.GetSpeedFunc = func() (speed float64) {
+			return 5
+		}
+		// - simulator: mock without result
+		sm := simulator.NewCatchSimulatorMock()
+		sm.CanCatchFunc = func(hunter, prey *simulator.Subject) (result *simulator.CatchResult, ok bool) {
+			return nil, false
+		}
+		// - hunter: white shark
+		impl := hunter.NewWhiteShark(hunter.ConfigWhiteShark{
+			Speed:     10,
+			Position:  &positioner.Position{X: 100, Y: 0, Z: 0},
+			Simulator: sm,
+		})
+
+		// act
+		result, err := impl.Hunt(pr)
+
+		// assert
+		require.ErrorIs(t, err, hunter.ErrCanNotHunt)
+		require.ErrorIs(t, err, hunter.ErrNoResult)
+		require.EqualError(t, err, "can not hunt the prey: shark can not catch the prey: the simulator gave no result")
+		// - an empty hunt, from the states it started from
+		expectedResult := &simulator.CatchResult{
+			HunterStart: &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 100, Y: 0, Z: 0}},
+			PreyStart:   &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 0, Y: 0, Z: 0}},
+		}
+		require.Equal(t, expectedResult, result)
+	})
 }
 
 func TestHunterWhiteShark_Configure(t *testing.T) {
@@ -157,4 +199,117 @@ func TestHunterWhiteShark_Configure(t *testing.T) {
 		// require.Equal(t, outputSpeed, impl.speed)
 		// require.Equal(t, outputPosition, impl.position)
 	})
-}
\ No newline at end of file
+}
+
+func TestHunterWhiteShark_Hunt_Strategy(t *testing.T) {
+	t.Run("white shark hands its pursuit strategy to the simulator", func(t *testing.T) {
+		// arrange
+		// - prey: stub
+		pr := prey.NewPreyStub()
+		pr.GetPositionFunc = func() (position *positioner.Position) {
+			return &positioner.Position{X: 0, Y: 0, Z: 0}
+		}
+		// - strategy: constant bearing
+		st := hunter.NewPursuitConstantBearing()
+		// - simulator: mock
+		var navigator simulator.Navigator
+		sm := simulator.NewCatchSimulatorMock()
+		sm.CanCatchFunc = func(hunter, prey *simulator.Subject) (result *simulator.CatchResult, ok bool) {
+			navigator = hunter.Navigator
+			return &simulator.CatchResult{Outcome: simulator.OutcomeCaught, Duration: 10.0}, true
+		}
+		// - hunter: white shark
+		impl := hunter.NewWhiteShark(hunter.ConfigWhiteShark{
+			Speed:     10,
+			Position:  &positioner.Position{X: 100, Y: 0, Z: 0},
+			Simulator: sm,
+			Strategy:  st,
+		})
+
+		// act
+		_, err := impl.Hunt(pr)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, st, navigator)
+	})
+}
+
+func TestHunterWhiteShark_Hunt_Perception(t *testing.T) {
+	t.Run("white shark can not hunt a prey it never senses", func(t *testing.T) {
+		// arrange
+		// - prey: tuna out of the sensing radius of the shark
+		pr := prey.NewTuna(0, &positioner.Position{X: 100, Y: 0, Z: 0})
+		// - simulator: stepped
+		sm := simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 30,
+			Positioner:     positioner.NewPositionerDefault(),
+		})
+		// - hunter: white shark
+		impl := hunter.NewWhiteShark(hunter.ConfigWhiteShark{
+			Speed:      10,
+			Position:   &positioner.Position{X: 0, Y: 0, Z: 0},
+			Simulator:  sm,
+			Perception: &simulator.Perception{Radius: 50},
+		})
+
+		// act
+		result, err := impl.Hunt(pr)
+
+		// assert
+		require.ErrorIs(t, err, hunter.ErrCanNotHunt)
+		require.ErrorIs(t, err, simulator.ErrNotDetected)
+		require.Equal(t, simulator.OutcomeNotDetected, result.Outcome)
+	})
+}
+
+func TestHunterWhiteShark_Hunt_Drag(t *testing.T) {
+	t.Run("white shark hands its drag and the one of the prey to the simulator", func(t *testing.T) {
+		// arrange
+		// - prey: tuna
+		pr := prey.NewTunaWithConfig(prey.ConfigTuna{Speed: 5, Position: &positioner.Position{}, Drag: 0.5})
+		// - simulator: mock
+		var hunterDrag, preyDrag float64
+		sm := simulator.NewCatchSimulatorMock()
+		sm.CanCatchFunc = func(hunter, prey *simulator.Subject) (result *simulator.CatchResult, ok bool) {
+			hunterDrag, preyDrag = hunter.Drag, prey.Drag
+			return &simulator.CatchResult{Outcome: simulator.OutcomeCaught, Duration: 10.0}, true
+		}
+		// - hunter: white shark
+		impl := hunter.NewWhiteShark(hunter.ConfigWhiteShark{
+			Speed:     10,
+			Position:  &positioner.Position{X: 100, Y: 0, Z: 0},
+			Simulator: sm,
+			Drag:      0.2,
+		})
+
+		// act
+		_, err := impl.Hunt(pr)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 0.2, hunterDrag)
+		require.Equal(t, 0.5, preyDrag)
+	})
+}
+
+func TestHunterWhiteShark_CreateWhiteShark(t *testing.T) {
+	t.Run("same seed, same shark", func(t *testing.T) {
+		// act
+		shark1 := hunter.CreateWhiteShark(nil, rand.NewSource(42))
+		shark2 := hunter.CreateWhiteShark(nil, rand.NewSource(42))
+
+		// assert
+		require.Equal(t, shark1.GetSpeed(), shark2.GetSpeed())
+		require.Equal(t, shark1.GetPosition(), shark2.GetPosition())
+	})
+
+	t.Run("different seed, different shark", func(t *testing.T) {
+		// act
+		shark1 := hunter.CreateWhiteShark(nil, rand.NewSource(1))
+		shark2 := hunter.CreateWhiteShark(nil, rand.NewSource(2))
+
+		// assert
+		require.NotEqual(t, shark1.GetPosition(), shark2.GetPosition())
+	})
+}
@@ -0,0 +1,30 @@
+package hunter
+
+import (
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+)
+
+// PursuitStrategy is an interface that represents how a hunter chases a prey
+// It is asked for the heading of the hunter on each step of a stepped simulation
+type PursuitStrategy interface {
+	// Heading returns the direction the hunter heads to
+	// - hunter: is the current state of the hunter
+	// - prey: is the current state of the prey
+	Heading(hunter, prey *simulator.Subject) (heading *positioner.Position)
+}
+
+// velocity returns the velocity of a subject (its heading scaled to its speed)
+func velocity(s *simulator.Subject) (v positioner.Position) {
+	if s.Heading == nil {
+		return
+	}
+	v = s.Heading.Normalize().Scale(s.Speed)
+	return
+}
+
+// direction returns the vector that goes from one position to another
+func direction(from, to *positioner.Position) *positioner.Position {
+	d := to.Sub(*from)
+	return &d
+}
@@ -0,0 +1,48 @@
+package hunter
+
+import (
+	"math"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+)
+
+// NewPursuitConstantBearing creates a new PursuitConstantBearing
+func NewPursuitConstantBearing() (ps *PursuitConstantBearing) {
+	ps = &PursuitConstantBearing{}
+	return
+}
+
+// PursuitConstantBearing is an implementation of PursuitStrategy
+// The hunter keeps the line of sight to the prey at a constant bearing (parallel navigation):
+// it matches the velocity of the prey across the line of sight and uses the rest of its speed to close in.
+// Against a prey with constant velocity it follows a straight collision course.
+type PursuitConstantBearing struct{}
+
+// Heading returns the direction that keeps the bearing to the prey constant
+func (p *PursuitConstantBearing) Heading(hunter, prey *simulator.Subject) (heading *positioner.Position) {
+	// line of sight (unit vector)
+	los := *direction(hunter.Position, prey.Position)
+	distance := los.Length()
+	if distance == 0 {
+		heading = &los
+		return
+	}
+	los = los.Scale(1 / distance)
+
+	// velocity of the prey across the line of sight
+	v := velocity(prey)
+	across := v.Sub(los.Scale(v.Dot(los)))
+
+	// the hunter can not match the prey across the line of sight: chase it directly
+	a := across.Length()
+	if a >= hunter.Speed {
+		heading = direction(hunter.Position, prey.Position)
+		return
+	}
+
+	// rest of the speed of the hunter along the line of sight
+	closing := math.Sqrt(hunter.Speed*hunter.Speed - a*a)
+	h := across.Add(los.Scale(closing))
+	heading = &h
+	return
+}
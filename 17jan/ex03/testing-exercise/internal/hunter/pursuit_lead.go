@@ -0,0 +1,47 @@
+package hunter
+
+import (
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+)
+
+// NewPursuitLead creates a new PursuitLead
+// - lookahead: scales how far ahead the hunter aims (1 if zero or negative)
+func NewPursuitLead(lookahead float64) (ps *PursuitLead) {
+	// default config
+	if lookahead <= 0 {
+		lookahead = 1
+	}
+
+	ps = &PursuitLead{lookahead: lookahead}
+	return
+}
+
+// PursuitLead is an implementation of PursuitStrategy
+// The hunter aims at the predicted intercept point: where the prey will be
+// by the time the hunter covers the current distance between them
+type PursuitLead struct {
+	// lookahead scales the predicted time to intercept
+	lookahead float64
+}
+
+// Heading returns the direction from the hunter to the predicted position of the prey
+func (p *PursuitLead) Heading(hunter, prey *simulator.Subject) (heading *positioner.Position) {
+	// without speed the hunter can not predict anything
+	if hunter.Speed <= 0 {
+		heading = direction(hunter.Position, prey.Position)
+		return
+	}
+
+	// predicted time to intercept (in seconds)
+	d := direction(hunter.Position, prey.Position)
+	distance := d.Length()
+	t := p.lookahead * distance / hunter.Speed
+
+	// predicted position of the prey
+	v := velocity(prey)
+	predicted := prey.Position.Add(v.Scale(t))
+
+	heading = direction(hunter.Position, &predicted)
+	return
+}
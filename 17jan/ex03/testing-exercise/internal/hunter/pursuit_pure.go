@@ -0,0 +1,22 @@
+package hunter
+
+import (
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+)
+
+// NewPursuitPure creates a new PursuitPure
+func NewPursuitPure() (ps *PursuitPure) {
+	ps = &PursuitPure{}
+	return
+}
+
+// PursuitPure is an implementation of PursuitStrategy
+// The hunter always heads to the current position of the prey
+type PursuitPure struct{}
+
+// Heading returns the direction from the hunter to the prey
+func (p *PursuitPure) Heading(hunter, prey *simulator.Subject) (heading *positioner.Position) {
+	heading = direction(hunter.Position, prey.Position)
+	return
+}
@@ -0,0 +1,115 @@
+package hunter_test
+
+import (
+	"math"
+	"testdoubles/internal/hunter"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for the implementations of PursuitStrategy
+func TestPursuitStrategy_Heading(t *testing.T) {
+	type input struct{ hunter, prey *simulator.Subject }
+	type output struct{ heading *positioner.Position }
+	type testCase struct {
+		name     string
+		strategy hunter.PursuitStrategy
+		input    input
+		output   output
+	}
+
+	// prey at (100, 0, 0) running along Y at 5 m/s, hunter at the origin at 10 m/s
+	hunterSubject := &simulator.Subject{Position: &positioner.Position{X: 0, Y: 0, Z: 0}, Speed: 10}
+	preySubject := &simulator.Subject{
+		Position: &positioner.Position{X: 100, Y: 0, Z: 0},
+		Speed:    5,
+		Heading:  &positioner.Position{X: 0, Y: 1, Z: 0},
+	}
+
+	cases := []testCase{
+		// case 1: pure pursuit aims at the prey
+		{
+			name:     "pure pursuit",
+			strategy: hunter.NewPursuitPure(),
+			input:    input{hunter: hunterSubject, prey: preySubject},
+			output:   output{heading: &positioner.Position{X: 100, Y: 0, Z: 0}},
+		},
+		// case 2: lead pursuit aims where the prey will be in distance / speed = 10 seconds
+		{
+			name:     "lead pursuit",
+			strategy: hunter.NewPursuitLead(1),
+			input:    input{hunter: hunterSubject, prey: preySubject},
+			output:   output{heading: &positioner.Position{X: 100, Y: 50, Z: 0}},
+		},
+		// case 3: constant bearing matches the prey across the line of sight
+		{
+			name:     "constant bearing",
+			strategy: hunter.NewPursuitConstantBearing(),
+			input:    input{hunter: hunterSubject, prey: preySubject},
+			output:   output{heading: &positioner.Position{X: math.Sqrt(75), Y: 5, Z: 0}},
+		},
+		// case 4: constant bearing chases directly a prey that is too fast across the line of sight
+		{
+			name:     "constant bearing - prey too fast",
+			strategy: hunter.NewPursuitConstantBearing(),
+			input: input{
+				hunter: &simulator.Subject{Position: &positioner.Position{X: 0, Y: 0, Z: 0}, Speed: 4},
+				prey:   preySubject,
+			},
+			output: output{heading: &positioner.Position{X: 100, Y: 0, Z: 0}},
+		},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// act
+			heading := c.strategy.Heading(c.input.hunter, c.input.prey)
+
+			// assert
+			require.InDelta(t, c.output.heading.X, heading.X, 1e-9)
+			require.InDelta(t, c.output.heading.Y, heading.Y, 1e-9)
+			require.InDelta(t, c.output.heading.Z, heading.Z, 1e-9)
+		})
+	}
+}
+
+// Tests comparing the catch time of each PursuitStrategy with a stepped simulation
+func TestPursuitStrategy_CatchTime(t *testing.T) {
+	// arrange
+	sm := simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+		MaxTimeToCatch: 100,
+		TimeStep:       0.01,
+		CaptureRadius:  0.01,
+		Positioner:     positioner.NewPositionerDefault(),
+	})
+	hunt := func(strategy hunter.PursuitStrategy) (duration float64) {
+		hunterSubject := &simulator.Subject{Position: &positioner.Position{X: 0, Y: 0, Z: 0}, Speed: 10, Navigator: strategy}
+		preySubject := &simulator.Subject{
+			Position: &positioner.Position{X: 100, Y: 0, Z: 0},
+			Speed:    5,
+			Heading:  &positioner.Position{X: 0, Y: 1, Z: 0},
+		}
+		result, ok := sm.CanCatch(hunterSubject, preySubject)
+		require.True(t, ok)
+		duration = result.Duration
+		return
+	}
+
+	// act
+	pure := hunt(hunter.NewPursuitPure())
+	lead := hunt(hunter.NewPursuitLead(1))
+	bearing := hunt(hunter.NewPursuitConstantBearing())
+
+	// assert
+	// -> pure pursuit of a prey running perpendicular: d * vh / (vh^2 - vp^2)
+	require.InDelta(t, 100.0*10.0/75.0, pure, 0.05)
+	// -> constant bearing follows the straight collision course: d / sqrt(vh^2 - vp^2)
+	require.InDelta(t, 100.0/math.Sqrt(75), bearing, 0.05)
+	// -> leading the prey is in between
+	require.Less(t, bearing, lead)
+	require.Less(t, lead, pure)
+}
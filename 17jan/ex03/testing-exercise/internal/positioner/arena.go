@@ -0,0 +1,204 @@
+package positioner
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// defaultArenaSize is the size of each side of the default arena (in meters)
+const defaultArenaSize = 500.0
+
+// maxDepth is the depth of the deepest seabed of the oceans (in meters)
+const maxDepth = 11000.0
+
+// ErrPositionOutOfArena is returned when a position is outside the arena
+var ErrPositionOutOfArena = errors.New("position is out of the arena")
+
+// BoundaryMode is what happens to a subject that crosses a boundary of the arena
+type BoundaryMode int
+
+const (
+	// BoundaryClamp stops the subject at the boundary
+	BoundaryClamp BoundaryMode = iota
+	// BoundaryReflect bounces the subject back into the arena, as off a wall
+	BoundaryReflect
+	// BoundaryWrap moves the subject to the opposite boundary (toroidal arena)
+	BoundaryWrap
+)
+
+// boundaryModes are the names of the boundary modes
+var boundaryModes = map[BoundaryMode]string{
+	BoundaryClamp:   "clamp",
+	BoundaryReflect: "reflect",
+	BoundaryWrap:    "wrap",
+}
+
+// String returns the name of the boundary mode
+func (m BoundaryMode) String() string {
+	name, ok := boundaryModes[m]
+	if !ok {
+		return "unknown"
+	}
+	return name
+}
+
+// MarshalText returns the name of the boundary mode (used in JSON)
+func (m BoundaryMode) MarshalText() (text []byte, err error) {
+	text = []byte(m.String())
+	return
+}
+
+// UnmarshalText parses the boundary mode from its name
+func (m *BoundaryMode) UnmarshalText(text []byte) (err error) {
+	for mode, name := range boundaryModes {
+		if name == string(text) {
+			*m = mode
+			return
+		}
+	}
+	err = fmt.Errorf("unknown boundary mode %q", text)
+	return
+}
+
+// Bounds are the min and max values of an axis of the arena
+type Bounds struct {
+	Min float64
+	Max float64
+}
+
+// ConfigArena is the configuration for Arena
+type ConfigArena struct {
+	// X, Y and Z are the bounds of each axis (Z goes from the seabed to the surface)
+	X Bounds
+	Y Bounds
+	Z Bounds
+	// Mode is what happens to a subject that crosses a boundary
+	Mode BoundaryMode
+}
+
+// NewArena creates a new Arena
+func NewArena(cfg ConfigArena) (a *Arena) {
+	a = &Arena{
+		min:  Position{X: cfg.X.Min, Y: cfg.Y.Min, Z: cfg.Z.Min},
+		max:  Position{X: cfg.X.Max, Y: cfg.Y.Max, Z: cfg.Z.Max},
+		mode: cfg.Mode,
+	}
+	return
+}
+
+// NewArenaDefault creates the default Arena: a box of 500 * 500 * 500 meters that clamps the subjects
+func NewArenaDefault() (a *Arena) {
+	a = NewArena(ConfigArena{
+		X: Bounds{Min: 0, Max: defaultArenaSize},
+		Y: Bounds{Min: 0, Max: defaultArenaSize},
+		Z: Bounds{Min: 0, Max: defaultArenaSize},
+	})
+	return
+}
+
+// NewArenaGeo creates an Arena for geographic positions, like the ones of PositionerHaversine, that clamps the subjects
+// - X is the latitude and Y the longitude (in degrees), and Z the depth down to the deepest seabed (in meters)
+func NewArenaGeo() (a *Arena) {
+	a = NewArena(ConfigArena{
+		X: Bounds{Min: -90, Max: 90},
+		Y: Bounds{Min: -180, Max: 180},
+		Z: Bounds{Min: 0, Max: maxDepth},
+	})
+	return
+}
+
+// Arena is the box where hunters and preys live
+// - the walls are the bounds of X and Y, the seabed and the surface are the bounds of Z
+type Arena struct {
+	// min and max corners of the arena
+	min Position
+	max Position
+	// mode is what happens to a subject that crosses a boundary
+	mode BoundaryMode
+}
+
+// Min returns the corner of the arena with the min value of each axis
+func (a *Arena) Min() (min Position) {
+	min = a.min
+	return
+}
+
+// Max returns the corner of the arena with the max value of each axis
+func (a *Arena) Max() (max Position) {
+	max = a.max
+	return
+}
+
+// Mode returns what happens to a subject that crosses a boundary
+func (a *Arena) Mode() (mode BoundaryMode) {
+	mode = a.mode
+	return
+}
+
+// Validate returns an error if the position is outside the arena
+func (a *Arena) Validate(position *Position) (err error) {
+	for _, axis := range []struct {
+		name        string
+		v, min, max float64
+	}{
+		{"X", position.X, a.min.X, a.max.X},
+		{"Y", position.Y, a.min.Y, a.max.Y},
+		{"Z", position.Z, a.min.Z, a.max.Z},
+	} {
+		if axis.v < axis.min || axis.v > axis.max {
+			err = fmt.Errorf("%w: %s must be between %g and %g", ErrPositionOutOfArena, axis.name, axis.min, axis.max)
+			return
+		}
+	}
+	return
+}
+
+// Constrain returns the position and the heading of a subject that moved to the position, kept inside the arena
+// - clamp: the position stops at the boundary, the heading does not change
+// - reflect: the position bounces back off the boundary, and the heading flips on that axis
+// - wrap: the position comes back from the opposite boundary, the heading does not change
+func (a *Arena) Constrain(position, heading Position) (p, h Position) {
+	p, h = position, heading
+	p.X, h.X = a.constrain(p.X, h.X, a.min.X, a.max.X)
+	p.Y, h.Y = a.constrain(p.Y, h.Y, a.min.Y, a.max.Y)
+	p.Z, h.Z = a.constrain(p.Z, h.Z, a.min.Z, a.max.Z)
+	return
+}
+
+// constrain keeps a coordinate v, moving with heading h, inside [min, max]
+func (a *Arena) constrain(v, h, min, max float64) (float64, float64) {
+	if v >= min && v <= max {
+		return v, h
+	}
+	size := max - min
+	if size <= 0 {
+		return min, h
+	}
+
+	switch a.mode {
+	case BoundaryReflect:
+		// unfold the path: it goes back and forth every 2 sizes
+		d := math.Mod(v-min, 2*size)
+		if d < 0 {
+			d += 2 * size
+		}
+		if d > size {
+			d = 2*size - d
+		}
+		// - the heading flips once per boundary crossed
+		crossings := int(math.Floor((v - min) / size))
+		if crossings%2 != 0 {
+			h = -h
+		}
+		return min + d, h
+	case BoundaryWrap:
+		d := math.Mod(v-min, size)
+		if d < 0 {
+			d += size
+		}
+		return min + d, h
+	default:
+		return math.Max(min, math.Min(max, v)), h
+	}
+}
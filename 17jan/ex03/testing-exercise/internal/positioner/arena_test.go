@@ -0,0 +1,105 @@
+package positioner_test
+
+import (
+	"testdoubles/internal/positioner"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for Arena
+func TestArena_Validate(t *testing.T) {
+	// arena: X in [0, 100], Y in [-50, 50], Z in [0, 20]
+	impl := positioner.NewArena(positioner.ConfigArena{
+		X: positioner.Bounds{Min: 0, Max: 100},
+		Y: positioner.Bounds{Min: -50, Max: 50},
+		Z: positioner.Bounds{Min: 0, Max: 20},
+	})
+
+	t.Run("inside the arena", func(t *testing.T) {
+		err := impl.Validate(&positioner.Position{X: 100, Y: -50, Z: 10})
+		require.NoError(t, err)
+	})
+
+	t.Run("below the seabed", func(t *testing.T) {
+		err := impl.Validate(&positioner.Position{X: 10, Y: 0, Z: -1})
+		require.ErrorIs(t, err, positioner.ErrPositionOutOfArena)
+		require.EqualError(t, err, "position is out of the arena: Z must be between 0 and 20")
+	})
+
+	t.Run("out of the walls", func(t *testing.T) {
+		err := impl.Validate(&positioner.Position{X: 10, Y: 51, Z: 0})
+		require.EqualError(t, err, "position is out of the arena: Y must be between -50 and 50")
+	})
+}
+
+func TestArena_Constrain(t *testing.T) {
+	type input struct{ position, heading positioner.Position }
+	type output struct{ position, heading positioner.Position }
+	type testCase struct {
+		name   string
+		mode   positioner.BoundaryMode
+		input  input
+		output output
+	}
+
+	cases := []testCase{
+		// case 1: a position inside the arena does not change
+		{
+			name:   "inside",
+			mode:   positioner.BoundaryReflect,
+			input:  input{position: positioner.Position{X: 5, Y: 5, Z: 5}, heading: positioner.Position{X: 1}},
+			output: output{position: positioner.Position{X: 5, Y: 5, Z: 5}, heading: positioner.Position{X: 1}},
+		},
+		// case 2: clamp stops at the wall
+		{
+			name:   "clamp",
+			mode:   positioner.BoundaryClamp,
+			input:  input{position: positioner.Position{X: 12, Y: -3, Z: 5}, heading: positioner.Position{X: 1, Y: -1}},
+			output: output{position: positioner.Position{X: 10, Y: 0, Z: 5}, heading: positioner.Position{X: 1, Y: -1}},
+		},
+		// case 3: reflect bounces off the surface
+		{
+			name:   "reflect",
+			mode:   positioner.BoundaryReflect,
+			input:  input{position: positioner.Position{X: 5, Y: 5, Z: 13}, heading: positioner.Position{Z: 2}},
+			output: output{position: positioner.Position{X: 5, Y: 5, Z: 7}, heading: positioner.Position{Z: -2}},
+		},
+		// case 4: reflect off both walls flips the heading twice
+		{
+			name:   "reflect twice",
+			mode:   positioner.BoundaryReflect,
+			input:  input{position: positioner.Position{X: 23, Y: 5, Z: 5}, heading: positioner.Position{X: 1}},
+			output: output{position: positioner.Position{X: 3, Y: 5, Z: 5}, heading: positioner.Position{X: 1}},
+		},
+		// case 5: wrap comes back from the other side
+		{
+			name:   "wrap",
+			mode:   positioner.BoundaryWrap,
+			input:  input{position: positioner.Position{X: -2, Y: 14, Z: 5}, heading: positioner.Position{X: -1, Y: 1}},
+			output: output{position: positioner.Position{X: 8, Y: 4, Z: 5}, heading: positioner.Position{X: -1, Y: 1}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// arrange
+			// - arena: a box of 10 * 10 * 10 meters
+			impl := positioner.NewArena(positioner.ConfigArena{
+				X:    positioner.Bounds{Min: 0, Max: 10},
+				Y:    positioner.Bounds{Min: 0, Max: 10},
+				Z:    positioner.Bounds{Min: 0, Max: 10},
+				Mode: c.mode,
+			})
+
+			// act
+			position, heading := impl.Constrain(c.input.position, c.input.heading)
+
+			// assert
+			require.InDelta(t, c.output.position.X, position.X, 1e-9)
+			require.InDelta(t, c.output.position.Y, position.Y, 1e-9)
+			require.InDelta(t, c.output.position.Z, position.Z, 1e-9)
+			require.Equal(t, c.output.heading, heading)
+		})
+	}
+}
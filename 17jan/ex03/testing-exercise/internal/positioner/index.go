@@ -0,0 +1,48 @@
+package positioner
+
+import "sort"
+
+// SpatialIndex is an interface that represents an index of positions by ID
+// It finds the positions near another one without scanning all of them
+type SpatialIndex interface {
+	// Insert adds the position of an ID (it moves it if the ID is already in the index)
+	Insert(id string, position Position)
+	// Move changes the position of an ID (it inserts it if the ID is not in the index)
+	Move(id string, position Position)
+	// Remove removes an ID from the index (nothing happens if the ID is not in the index)
+	Remove(id string)
+	// Len returns the number of IDs in the index
+	Len() (n int)
+	// Nearest returns the k IDs nearest to a position, nearest first
+	Nearest(position Position, k int) (ids []string)
+	// Within returns the IDs at a distance of radius or less from a position, nearest first
+	Within(position Position, radius float64) (ids []string)
+}
+
+// neighbor is an ID found by a query, with its distance to the position of the query
+type neighbor struct {
+	id       string
+	distance float64
+}
+
+// sortNeighbors sorts the neighbors nearest first (by ID on a tie, so the order does not depend on the index)
+func sortNeighbors(neighbors []neighbor) {
+	sort.Slice(neighbors, func(i, j int) bool {
+		if neighbors[i].distance != neighbors[j].distance {
+			return neighbors[i].distance < neighbors[j].distance
+		}
+		return neighbors[i].id < neighbors[j].id
+	})
+}
+
+// idsOf returns the IDs of the neighbors (nil if there is none)
+func idsOf(neighbors []neighbor) (ids []string) {
+	if len(neighbors) == 0 {
+		return
+	}
+	ids = make([]string, len(neighbors))
+	for i, n := range neighbors {
+		ids[i] = n.id
+	}
+	return
+}
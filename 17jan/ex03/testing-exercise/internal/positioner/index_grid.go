@@ -0,0 +1,313 @@
+package positioner
+
+import "math"
+
+// defaultIndexCellSize is the default size of each side of the cells of the index (in meters)
+const defaultIndexCellSize = 10.0
+
+// cellKey is the coordinates of a cell of a grid
+type cellKey [3]int
+
+// NewIndexGrid creates a new IndexGrid
+// - cellSize: is the size of each side of the cells in meters (default: 10)
+func NewIndexGrid(cellSize float64) (ix *IndexGrid) {
+	// default config
+	if cellSize <= 0 {
+		cellSize = defaultIndexCellSize
+	}
+
+	ix = &IndexGrid{
+		cellSize:  cellSize,
+		cells:     make(map[cellKey]map[string]Position),
+		positions: make(map[string]Position),
+	}
+	return
+}
+
+// IndexGrid is an implementation of SpatialIndex
+// The positions are kept in the cells of a uniform grid, and only the cells around a query are looked at
+// - it works best when the cells are about the size of the radius of the queries
+type IndexGrid struct {
+	// cellSize is the size of each side of the cells in meters
+	cellSize float64
+	// cells with positions, and the positions in each one by ID
+	cells map[cellKey]map[string]Position
+	// positions by ID
+	positions map[string]Position
+	// min and max are the bounds of the cells with positions
+	min cellKey
+	max cellKey
+}
+
+// Insert adds the position of an ID
+func (ix *IndexGrid) Insert(id string, position Position) {
+	ix.Remove(id)
+
+	key := ix.key(position)
+	cell, ok := ix.cells[key]
+	if !ok {
+		cell = make(map[string]Position)
+		ix.cells[key] = cell
+	}
+	cell[id] = position
+	ix.positions[id] = position
+
+	// - grow the bounds (or start them over if the index was empty)
+	if len(ix.positions) == 1 {
+		ix.min, ix.max = key, key
+	}
+	for axis := range key {
+		if key[axis] < ix.min[axis] {
+			ix.min[axis] = key[axis]
+		}
+		if key[axis] > ix.max[axis] {
+			ix.max[axis] = key[axis]
+		}
+	}
+}
+
+// Move changes the position of an ID
+func (ix *IndexGrid) Move(id string, position Position) {
+	ix.Insert(id, position)
+}
+
+// Remove removes an ID from the index
+func (ix *IndexGrid) Remove(id string) {
+	position, ok := ix.positions[id]
+	if !ok {
+		return
+	}
+	key := ix.key(position)
+	delete(ix.cells[key], id)
+	delete(ix.positions, id)
+	if len(ix.cells[key]) == 0 {
+		delete(ix.cells, key)
+		// - shrink the bounds if the cell was on them, so a far position does not widen the queries once it is gone
+		if ix.onBounds(key) {
+			ix.bound()
+		}
+	}
+}
+
+// Len returns the number of IDs in the index
+func (ix *IndexGrid) Len() (n int) {
+	n = len(ix.positions)
+	return
+}
+
+// Nearest returns the k IDs nearest to a position, nearest first
+// - it looks at the shells of cells around the cell of the position, until no closer position can be left
+// - once a shell has more cells than there are cells with positions, it looks at those instead
+func (ix *IndexGrid) Nearest(position Position, k int) (ids []string) {
+	if k <= 0 || len(ix.positions) == 0 {
+		return
+	}
+
+	center := ix.key(position)
+	var found []neighbor
+	for shell := ix.gap(center); ; shell++ {
+		if ix.surface(center, shell) > len(ix.cells) {
+			found = found[:0]
+			for _, cell := range ix.cells {
+				for id, p := range cell {
+					found = append(found, neighbor{id: id, distance: position.Distance(p)})
+				}
+			}
+			sortNeighbors(found)
+			if len(found) > k {
+				found = found[:k]
+			}
+			break
+		}
+		ix.visit(center, shell, func(cell map[string]Position) {
+			for id, p := range cell {
+				found = append(found, neighbor{id: id, distance: position.Distance(p)})
+			}
+		})
+
+		// - the cells of the next shell are at least shell cells away
+		if len(found) >= k {
+			sortNeighbors(found)
+			if found[k-1].distance <= float64(shell)*ix.cellSize {
+				found = found[:k]
+				break
+			}
+		}
+		// - every cell with positions has been looked at
+		if ix.covers(center, shell) {
+			sortNeighbors(found)
+			if len(found) > k {
+				found = found[:k]
+			}
+			break
+		}
+	}
+	ids = idsOf(found)
+	return
+}
+
+// Within returns the IDs at a distance of radius or less from a position, nearest first
+func (ix *IndexGrid) Within(position Position, radius float64) (ids []string) {
+	if radius < 0 || len(ix.positions) == 0 {
+		return
+	}
+
+	// - the cells the sphere of the query touches, inside the bounds
+	from := ix.key(Position{X: position.X - radius, Y: position.Y - radius, Z: position.Z - radius})
+	to := ix.key(Position{X: position.X + radius, Y: position.Y + radius, Z: position.Z + radius})
+	for axis := range from {
+		from[axis] = maxInt(from[axis], ix.min[axis])
+		to[axis] = minInt(to[axis], ix.max[axis])
+	}
+
+	var found []neighbor
+	for x := from[0]; x <= to[0]; x++ {
+		for y := from[1]; y <= to[1]; y++ {
+			for z := from[2]; z <= to[2]; z++ {
+				for id, p := range ix.cells[cellKey{x, y, z}] {
+					if d := position.Distance(p); d <= radius {
+						found = append(found, neighbor{id: id, distance: d})
+					}
+				}
+			}
+		}
+	}
+	sortNeighbors(found)
+	ids = idsOf(found)
+	return
+}
+
+// visit calls fn with every cell with positions at shell cells from the center (the surface of a cube)
+// - only the cells of the 6 faces inside the bounds are looked at
+func (ix *IndexGrid) visit(center cellKey, shell int, fn func(cell map[string]Position)) {
+	from, to := ix.clip(center, shell)
+	for x := from[0]; x <= to[0]; x++ {
+		for y := from[1]; y <= to[1]; y++ {
+			// - on a face of x or y: the whole row of z
+			if absInt(x-center[0]) == shell || absInt(y-center[1]) == shell {
+				for z := from[2]; z <= to[2]; z++ {
+					if cell, ok := ix.cells[cellKey{x, y, z}]; ok {
+						fn(cell)
+					}
+				}
+				continue
+			}
+			// - otherwise: only the faces of z
+			for _, z := range [2]int{center[2] - shell, center[2] + shell} {
+				if z < from[2] || z > to[2] {
+					continue
+				}
+				if cell, ok := ix.cells[cellKey{x, y, z}]; ok {
+					fn(cell)
+				}
+			}
+		}
+	}
+}
+
+// surface returns how many cells of the shell around the center are inside the bounds
+func (ix *IndexGrid) surface(center cellKey, shell int) (n int) {
+	n = ix.volume(center, shell)
+	if shell > 0 {
+		n -= ix.volume(center, shell-1)
+	}
+	return
+}
+
+// volume returns how many cells of the cube of shell cells around the center are inside the bounds
+func (ix *IndexGrid) volume(center cellKey, shell int) (n int) {
+	from, to := ix.clip(center, shell)
+	n = 1
+	for axis := range center {
+		n *= maxInt(0, to[axis]-from[axis]+1)
+	}
+	return
+}
+
+// clip returns the corners of the cube of shell cells around the center, inside the bounds
+func (ix *IndexGrid) clip(center cellKey, shell int) (from, to cellKey) {
+	for axis := range center {
+		from[axis] = maxInt(center[axis]-shell, ix.min[axis])
+		to[axis] = minInt(center[axis]+shell, ix.max[axis])
+	}
+	return
+}
+
+// onBounds returns true if a cell is on a face of the bounds
+func (ix *IndexGrid) onBounds(key cellKey) (ok bool) {
+	for axis := range key {
+		if key[axis] == ix.min[axis] || key[axis] == ix.max[axis] {
+			ok = true
+			return
+		}
+	}
+	return
+}
+
+// bound sets the bounds to the ones of the cells with positions
+func (ix *IndexGrid) bound() {
+	first := true
+	for key := range ix.cells {
+		if first {
+			ix.min, ix.max, first = key, key, false
+			continue
+		}
+		for axis := range key {
+			ix.min[axis] = minInt(ix.min[axis], key[axis])
+			ix.max[axis] = maxInt(ix.max[axis], key[axis])
+		}
+	}
+}
+
+// gap returns how many shells of cells are between the center and the bounds (0 if the center is inside)
+func (ix *IndexGrid) gap(center cellKey) (shells int) {
+	for axis := range center {
+		shells = maxInt(shells, maxInt(ix.min[axis]-center[axis], center[axis]-ix.max[axis]))
+	}
+	return
+}
+
+// covers returns true if the cube of shell cells around the center contains the bounds
+func (ix *IndexGrid) covers(center cellKey, shell int) (ok bool) {
+	for axis := range center {
+		if center[axis]-shell > ix.min[axis] || center[axis]+shell < ix.max[axis] {
+			return
+		}
+	}
+	ok = true
+	return
+}
+
+// key returns the cell of a position
+func (ix *IndexGrid) key(position Position) (key cellKey) {
+	key = cellKey{
+		int(math.Floor(position.X / ix.cellSize)),
+		int(math.Floor(position.Y / ix.cellSize)),
+		int(math.Floor(position.Z / ix.cellSize)),
+	}
+	return
+}
+
+// minInt returns the lowest of 2 ints
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// maxInt returns the highest of 2 ints
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// absInt returns the absolute value of an int
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
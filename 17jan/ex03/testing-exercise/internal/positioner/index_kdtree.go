@@ -0,0 +1,216 @@
+package positioner
+
+import (
+	"math"
+	"sort"
+)
+
+// NewIndexKDTree creates a new IndexKDTree
+func NewIndexKDTree() (ix *IndexKDTree) {
+	ix = &IndexKDTree{nodes: make(map[string]*kdNode)}
+	return
+}
+
+// IndexKDTree is an implementation of SpatialIndex
+// The positions are kept in a k-d tree that splits the space along X, Y and Z in turns
+// - removed and moved positions are only marked as gone, and the tree is rebuilt balanced
+// once it had as many changes as positions when it was built
+// - it does not need a cell size, so it works for queries of any radius
+type IndexKDTree struct {
+	// root of the tree
+	root *kdNode
+	// nodes of the tree that are not gone, by ID
+	nodes map[string]*kdNode
+	// built is the number of positions when the tree was built
+	built int
+	// changes is the number of inserts, moves and removes since the tree was built
+	changes int
+}
+
+// kdNode is a node of a k-d tree
+type kdNode struct {
+	id       string
+	position Position
+	// axis the node splits the space along (0: X, 1: Y, 2: Z)
+	axis        int
+	left, right *kdNode
+	// gone is true if the position was removed or moved
+	gone bool
+}
+
+// Insert adds the position of an ID
+func (ix *IndexKDTree) Insert(id string, position Position) {
+	ix.Remove(id)
+
+	node := &kdNode{id: id, position: position}
+	ix.nodes[id] = node
+	ix.changes++
+	if ix.changes > ix.built {
+		ix.rebuild()
+		return
+	}
+
+	// - down the tree to an empty leaf
+	link := &ix.root
+	for *link != nil {
+		parent := *link
+		node.axis = (parent.axis + 1) % 3
+		if coordinate(position, parent.axis) < coordinate(parent.position, parent.axis) {
+			link = &parent.left
+		} else {
+			link = &parent.right
+		}
+	}
+	*link = node
+}
+
+// Move changes the position of an ID
+func (ix *IndexKDTree) Move(id string, position Position) {
+	ix.Insert(id, position)
+}
+
+// Remove removes an ID from the index
+func (ix *IndexKDTree) Remove(id string) {
+	node, ok := ix.nodes[id]
+	if !ok {
+		return
+	}
+	node.gone = true
+	delete(ix.nodes, id)
+	ix.changes++
+}
+
+// Len returns the number of IDs in the index
+func (ix *IndexKDTree) Len() (n int) {
+	n = len(ix.nodes)
+	return
+}
+
+// Nearest returns the k IDs nearest to a position, nearest first
+func (ix *IndexKDTree) Nearest(position Position, k int) (ids []string) {
+	if k <= 0 {
+		return
+	}
+
+	// best are the nearest found so far, nearest first
+	var best []neighbor
+	var search func(n *kdNode)
+	search = func(n *kdNode) {
+		if n == nil {
+			return
+		}
+		if !n.gone {
+			best = insertNeighbor(best, neighbor{id: n.id, distance: position.Distance(n.position)}, k)
+		}
+
+		// - the side of the position first, the other side only if it can be closer than the worst found
+		diff := coordinate(position, n.axis) - coordinate(n.position, n.axis)
+		near, far := n.left, n.right
+		if diff >= 0 {
+			near, far = n.right, n.left
+		}
+		search(near)
+		if len(best) < k || math.Abs(diff) <= best[len(best)-1].distance {
+			search(far)
+		}
+	}
+	search(ix.root)
+	ids = idsOf(best)
+	return
+}
+
+// Within returns the IDs at a distance of radius or less from a position, nearest first
+func (ix *IndexKDTree) Within(position Position, radius float64) (ids []string) {
+	if radius < 0 {
+		return
+	}
+
+	var found []neighbor
+	var search func(n *kdNode)
+	search = func(n *kdNode) {
+		if n == nil {
+			return
+		}
+		if !n.gone {
+			if d := position.Distance(n.position); d <= radius {
+				found = append(found, neighbor{id: n.id, distance: d})
+			}
+		}
+
+		// - each side only if the sphere of the query reaches it
+		diff := coordinate(position, n.axis) - coordinate(n.position, n.axis)
+		if diff < radius {
+			search(n.left)
+		}
+		if diff >= -radius {
+			search(n.right)
+		}
+	}
+	search(ix.root)
+	sortNeighbors(found)
+	ids = idsOf(found)
+	return
+}
+
+// rebuild builds the tree again, balanced, with the positions that are not gone
+func (ix *IndexKDTree) rebuild() {
+	nodes := make([]*kdNode, 0, len(ix.nodes))
+	for _, n := range ix.nodes {
+		n.left, n.right = nil, nil
+		nodes = append(nodes, n)
+	}
+	// - the order of a map is random: sort the nodes so the same positions build the same tree
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].id < nodes[j].id })
+
+	ix.root = build(nodes, 0)
+	ix.built, ix.changes = len(nodes), 0
+}
+
+// build returns the root of a balanced tree of the nodes, split by the median along the axis
+func build(nodes []*kdNode, axis int) (root *kdNode) {
+	if len(nodes) == 0 {
+		return
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return coordinate(nodes[i].position, axis) < coordinate(nodes[j].position, axis)
+	})
+	median := len(nodes) / 2
+	// - equal coordinates go to the right, as on insert
+	for median > 0 && coordinate(nodes[median-1].position, axis) == coordinate(nodes[median].position, axis) {
+		median--
+	}
+
+	root = nodes[median]
+	root.axis = axis
+	root.left = build(nodes[:median], (axis+1)%3)
+	root.right = build(nodes[median+1:], (axis+1)%3)
+	return
+}
+
+// insertNeighbor inserts a neighbor in a list sorted nearest first, keeping only the k nearest
+func insertNeighbor(neighbors []neighbor, n neighbor, k int) []neighbor {
+	i := sort.Search(len(neighbors), func(i int) bool {
+		return neighbors[i].distance > n.distance || (neighbors[i].distance == n.distance && neighbors[i].id > n.id)
+	})
+	if i >= k {
+		return neighbors
+	}
+	if len(neighbors) < k {
+		neighbors = append(neighbors, neighbor{})
+	}
+	copy(neighbors[i+1:], neighbors[i:])
+	neighbors[i] = n
+	return neighbors
+}
+
+// coordinate returns the coordinate of a position along an axis (0: X, 1: Y, 2: Z)
+func coordinate(position Position, axis int) float64 {
+	switch axis {
+	case 0:
+		return position.X
+	case 1:
+		return position.Y
+	default:
+		return position.Z
+	}
+}
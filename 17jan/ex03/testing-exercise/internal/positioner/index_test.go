@@ -0,0 +1,254 @@
+package positioner_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testdoubles/internal/positioner"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// randomPositions returns n random positions in a cube of 500 meters, by ID
+func randomPositions(rd *rand.Rand, n int) (positions map[string]positioner.Position) {
+	positions = make(map[string]positioner.Position, n)
+	for i := 0; i < n; i++ {
+		positions[fmt.Sprintf("id-%d", i)] = positioner.Position{X: rd.Float64() * 500, Y: rd.Float64() * 500, Z: rd.Float64() * 500}
+	}
+	return
+}
+
+// bruteForce returns the IDs sorted nearest first (by ID on a tie), scanning all the positions
+func bruteForce(positions map[string]positioner.Position, position positioner.Position) (ids []string) {
+	for id := range positions {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		di, dj := position.Distance(positions[ids[i]]), position.Distance(positions[ids[j]])
+		if di != dj {
+			return di < dj
+		}
+		return ids[i] < ids[j]
+	})
+	return
+}
+
+// bruteForceWithin returns the IDs at a distance of radius or less, nearest first, scanning all the positions
+func bruteForceWithin(positions map[string]positioner.Position, position positioner.Position, radius float64) (ids []string) {
+	for _, id := range bruteForce(positions, position) {
+		if position.Distance(positions[id]) > radius {
+			break
+		}
+		ids = append(ids, id)
+	}
+	return
+}
+
+// indexes returns a new instance of each implementation of SpatialIndex
+func indexes() map[string]positioner.SpatialIndex {
+	return map[string]positioner.SpatialIndex{
+		"grid":   positioner.NewIndexGrid(25),
+		"kdtree": positioner.NewIndexKDTree(),
+	}
+}
+
+// Tests for the implementations of SpatialIndex
+func TestSpatialIndex(t *testing.T) {
+	for name, ix := range indexes() {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			rd := rand.New(rand.NewSource(1))
+			positions := randomPositions(rd, 500)
+			for id, p := range positions {
+				ix.Insert(id, p)
+			}
+			// - move and remove some of them
+			for i := 0; i < 100; i++ {
+				id := fmt.Sprintf("id-%d", i)
+				positions[id] = positioner.Position{X: rd.Float64() * 500, Y: rd.Float64() * 500, Z: rd.Float64() * 500}
+				ix.Move(id, positions[id])
+			}
+			for i := 100; i < 150; i++ {
+				id := fmt.Sprintf("id-%d", i)
+				delete(positions, id)
+				ix.Remove(id)
+			}
+			ix.Remove("unknown")
+
+			// act & assert
+			require.Equal(t, len(positions), ix.Len())
+			for i := 0; i < 20; i++ {
+				query := positioner.Position{X: rd.Float64()*700 - 100, Y: rd.Float64()*700 - 100, Z: rd.Float64()*700 - 100}
+				expected := bruteForce(positions, query)
+
+				require.Equal(t, expected[:10], ix.Nearest(query, 10))
+				require.Equal(t, expected[:1], ix.Nearest(query, 1))
+				require.Equal(t, bruteForceWithin(positions, query, 60), ix.Within(query, 60))
+			}
+			// - more than there are
+			require.Equal(t, bruteForce(positions, positioner.Position{}), ix.Nearest(positioner.Position{}, 1000))
+		})
+	}
+}
+
+func TestSpatialIndex_Empty(t *testing.T) {
+	for name, ix := range indexes() {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			ix.Insert("a", positioner.Position{X: 1})
+			ix.Remove("a")
+
+			// act & assert
+			require.Equal(t, 0, ix.Len())
+			require.Empty(t, ix.Nearest(positioner.Position{}, 3))
+			require.Empty(t, ix.Within(positioner.Position{}, 100))
+		})
+	}
+}
+
+func TestSpatialIndex_Outlier(t *testing.T) {
+	for name, ix := range indexes() {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			// - a cluster and a position far away from it
+			rd := rand.New(rand.NewSource(1))
+			positions := randomPositions(rd, 100)
+			for id, p := range positions {
+				ix.Insert(id, p)
+			}
+			positions["far"] = positioner.Position{X: 1500, Y: 1500, Z: 1500}
+			ix.Insert("far", positions["far"])
+			query := positioner.Position{X: 250, Y: 250, Z: 250}
+
+			// act & assert
+			require.Equal(t, bruteForce(positions, query), ix.Nearest(query, len(positions)))
+			require.Equal(t, []string{"far"}, ix.Nearest(positioner.Position{X: 1400, Y: 1400, Z: 1400}, 1))
+			// - once it is gone
+			delete(positions, "far")
+			ix.Remove("far")
+			require.Equal(t, bruteForce(positions, query), ix.Nearest(query, len(positions)+1))
+			require.Equal(t, bruteForce(positions, positioner.Position{X: 1400, Y: 1400, Z: 1400})[:1], ix.Nearest(positioner.Position{X: 1400, Y: 1400, Z: 1400}, 1))
+		})
+	}
+}
+
+// Benchmarks of the implementations of SpatialIndex against brute force
+func BenchmarkSpatialIndex_Nearest(b *testing.B) {
+	rd := rand.New(rand.NewSource(1))
+	positions := randomPositions(rd, 10000)
+	queries := make([]positioner.Position, 1000)
+	for i := range queries {
+		queries[i] = positioner.Position{X: rd.Float64() * 500, Y: rd.Float64() * 500, Z: rd.Float64() * 500}
+	}
+
+	b.Run("brute force", func(b *testing.B) {
+		list := make([]positioner.Position, 0, len(positions))
+		for _, p := range positions {
+			list = append(list, p)
+		}
+		ps := positioner.NewPositionerDefault()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			query := queries[i%len(queries)]
+			best, nearest := -1.0, 0
+			for j := range list {
+				if d := ps.GetLinearDistance(&query, &list[j]); best < 0 || d < best {
+					best, nearest = d, j
+				}
+			}
+			_ = nearest
+		}
+	})
+	for name, ix := range indexes() {
+		for id, p := range positions {
+			ix.Insert(id, p)
+		}
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ix.Nearest(queries[i%len(queries)], 1)
+			}
+		})
+	}
+}
+
+func BenchmarkSpatialIndex_Within(b *testing.B) {
+	rd := rand.New(rand.NewSource(1))
+	positions := randomPositions(rd, 10000)
+	queries := make([]positioner.Position, 1000)
+	for i := range queries {
+		queries[i] = positioner.Position{X: rd.Float64() * 500, Y: rd.Float64() * 500, Z: rd.Float64() * 500}
+	}
+
+	b.Run("brute force", func(b *testing.B) {
+		list := make([]positioner.Position, 0, len(positions))
+		for _, p := range positions {
+			list = append(list, p)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			query := queries[i%len(queries)]
+			var found []int
+			for j := range list {
+				if query.Distance(list[j]) <= 25 {
+					found = append(found, j)
+				}
+			}
+			_ = found
+		}
+	})
+	for name, ix := range indexes() {
+		for id, p := range positions {
+			ix.Insert(id, p)
+		}
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ix.Within(queries[i%len(queries)], 25)
+			}
+		})
+	}
+}
+
+func BenchmarkSpatialIndex_Move(b *testing.B) {
+	rd := rand.New(rand.NewSource(1))
+	positions := randomPositions(rd, 10000)
+	ids := make([]string, 0, len(positions))
+	for id := range positions {
+		ids = append(ids, id)
+	}
+
+	for name, ix := range indexes() {
+		for id, p := range positions {
+			ix.Insert(id, p)
+		}
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ix.Move(ids[i%len(ids)], positioner.Position{X: rd.Float64() * 500, Y: rd.Float64() * 500, Z: rd.Float64() * 500})
+			}
+		})
+	}
+}
+
+// Benchmark of a sparse index: a cluster and a position far away from it, with small cells
+func BenchmarkIndexGrid_Nearest_Outlier(b *testing.B) {
+	rd := rand.New(rand.NewSource(1))
+	positions := randomPositions(rd, 100)
+	query := positioner.Position{X: 250, Y: 250, Z: 250}
+
+	ix := positioner.NewIndexGrid(10)
+	for id, p := range positions {
+		ix.Insert(id, p)
+	}
+	ix.Insert("far", positioner.Position{X: 1500, Y: 1500, Z: 1500})
+	b.Run("with the outlier", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ix.Nearest(query, len(positions)+1)
+		}
+	})
+	ix.Remove("far")
+	b.Run("outlier removed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ix.Nearest(query, len(positions)+1)
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package positioner
+
+// Obstacle is an interface that represents a solid obstacle in the arena (a rock, a reef, ...)
+// Subjects can not see through obstacles
+type Obstacle interface {
+	// Contains returns true if the position is inside the obstacle
+	Contains(position Position) (ok bool)
+	// Intersects returns true if the segment from one position to another touches the obstacle
+	Intersects(from, to Position) (ok bool)
+	// Bounds returns the corners of the box that contains the obstacle
+	Bounds() (min, max Position)
+}
+
+// Router is an interface that represents a positioner that knows how to go around obstacles
+// - its distance between 2 positions is the length of the path (infinite if there is none)
+type Router interface {
+	// Path returns the positions to go through from one position to another, both included
+	// - ok is false if there is no path
+	Path(from, to *Position) (path []Position, ok bool)
+}
+
+// LineOfSight is an interface that represents a positioner that knows what can be seen
+type LineOfSight interface {
+	// HasLineOfSight returns true if no obstacle is between 2 positions
+	HasLineOfSight(from, to *Position) (ok bool)
+}
@@ -0,0 +1,59 @@
+package positioner
+
+import "math"
+
+// NewObstacleBox creates a new ObstacleBox
+func NewObstacleBox(min, max Position) (o *ObstacleBox) {
+	o = &ObstacleBox{min: min, max: max}
+	return
+}
+
+// ObstacleBox is an implementation of Obstacle
+// A box aligned with the axes, like a wreck or a wall
+type ObstacleBox struct {
+	// min and max corners of the box
+	min Position
+	max Position
+}
+
+// Contains returns true if the position is inside the box
+func (o *ObstacleBox) Contains(position Position) (ok bool) {
+	ok = position.X >= o.min.X && position.X <= o.max.X &&
+		position.Y >= o.min.Y && position.Y <= o.max.Y &&
+		position.Z >= o.min.Z && position.Z <= o.max.Z
+	return
+}
+
+// Intersects returns true if the segment goes through the box (slab method)
+func (o *ObstacleBox) Intersects(from, to Position) (ok bool) {
+	d := to.Sub(from)
+	tMin, tMax := 0.0, 1.0
+	for _, axis := range [][4]float64{
+		{from.X, d.X, o.min.X, o.max.X},
+		{from.Y, d.Y, o.min.Y, o.max.Y},
+		{from.Z, d.Z, o.min.Z, o.max.Z},
+	} {
+		p, v, min, max := axis[0], axis[1], axis[2], axis[3]
+		if v == 0 {
+			// parallel to the slab: it must be between its planes
+			if p < min || p > max {
+				return
+			}
+			continue
+		}
+		t1, t2 := (min-p)/v, (max-p)/v
+		tMin = math.Max(tMin, math.Min(t1, t2))
+		tMax = math.Min(tMax, math.Max(t1, t2))
+		if tMin > tMax {
+			return
+		}
+	}
+	ok = true
+	return
+}
+
+// Bounds returns the corners of the box
+func (o *ObstacleBox) Bounds() (min, max Position) {
+	min, max = o.min, o.max
+	return
+}
@@ -0,0 +1,171 @@
+package positioner
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrMeshInvalid is returned when a mesh file can not be parsed
+var ErrMeshInvalid = errors.New("invalid mesh")
+
+// Triangle is a face of a mesh
+type Triangle [3]Position
+
+// NewObstacleMesh creates a new ObstacleMesh
+func NewObstacleMesh(triangles []Triangle) (o *ObstacleMesh) {
+	o = &ObstacleMesh{triangles: triangles}
+	if len(triangles) == 0 {
+		return
+	}
+	o.min, o.max = triangles[0][0], triangles[0][0]
+	for _, tr := range triangles {
+		for _, v := range tr {
+			o.min = Position{X: math.Min(o.min.X, v.X), Y: math.Min(o.min.Y, v.Y), Z: math.Min(o.min.Z, v.Z)}
+			o.max = Position{X: math.Max(o.max.X, v.X), Y: math.Max(o.max.Y, v.Y), Z: math.Max(o.max.Z, v.Z)}
+		}
+	}
+	return
+}
+
+// LoadObstacleMesh reads a mesh in Wavefront OBJ format (only "v" and "f" lines, the rest is ignored)
+// - faces with more than 3 vertices are split in triangles
+func LoadObstacleMesh(r io.Reader) (o *ObstacleMesh, err error) {
+	var vertices []Position
+	var triangles []Triangle
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "v":
+			if len(fields) < 4 {
+				err = fmt.Errorf("%w: line %d: a vertex needs 3 coordinates", ErrMeshInvalid, line)
+				return
+			}
+			var c [3]float64
+			for i := range c {
+				c[i], err = strconv.ParseFloat(fields[i+1], 64)
+				if err != nil {
+					err = fmt.Errorf("%w: line %d: %v", ErrMeshInvalid, line, err)
+					return
+				}
+			}
+			vertices = append(vertices, Position{X: c[0], Y: c[1], Z: c[2]})
+		case "f":
+			if len(fields) < 4 {
+				err = fmt.Errorf("%w: line %d: a face needs 3 vertices", ErrMeshInvalid, line)
+				return
+			}
+			face := make([]Position, 0, len(fields)-1)
+			for _, f := range fields[1:] {
+				// - "v", "v/vt", "v//vn" or "v/vt/vn", and negative indexes count from the end
+				var i int
+				i, err = strconv.Atoi(strings.SplitN(f, "/", 2)[0])
+				if err != nil {
+					err = fmt.Errorf("%w: line %d: %v", ErrMeshInvalid, line, err)
+					return
+				}
+				if i < 0 {
+					i = len(vertices) + i + 1
+				}
+				if i < 1 || i > len(vertices) {
+					err = fmt.Errorf("%w: line %d: vertex %s does not exist", ErrMeshInvalid, line, f)
+					return
+				}
+				face = append(face, vertices[i-1])
+			}
+			for k := 1; k+1 < len(face); k++ {
+				triangles = append(triangles, Triangle{face[0], face[k], face[k+1]})
+			}
+		}
+	}
+	err = scanner.Err()
+	if err != nil {
+		return
+	}
+
+	o = NewObstacleMesh(triangles)
+	return
+}
+
+// ObstacleMesh is an implementation of Obstacle
+// A closed mesh of triangles, like a reef
+type ObstacleMesh struct {
+	// triangles of the mesh
+	triangles []Triangle
+	// corners of the box that contains the mesh
+	min Position
+	max Position
+}
+
+// Contains returns true if the position is inside the mesh
+// - a ray from the position crosses a closed mesh an odd number of times when it is inside
+func (o *ObstacleMesh) Contains(position Position) (ok bool) {
+	if !NewObstacleBox(o.min, o.max).Contains(position) {
+		return
+	}
+	// - the ray is slightly tilted, so it does not go along the edges of an axis-aligned mesh
+	far := position.Add(Position{X: 1, Y: 1e-3, Z: 2e-3}.Scale(2*o.max.Sub(o.min).Length() + 1))
+	crossings := 0
+	for _, tr := range o.triangles {
+		if _, hit := intersectTriangle(position, far, tr); hit {
+			crossings++
+		}
+	}
+	ok = crossings%2 == 1
+	return
+}
+
+// Intersects returns true if the segment crosses a face of the mesh or is inside it
+func (o *ObstacleMesh) Intersects(from, to Position) (ok bool) {
+	if !NewObstacleBox(o.min, o.max).Intersects(from, to) {
+		return
+	}
+	for _, tr := range o.triangles {
+		if _, hit := intersectTriangle(from, to, tr); hit {
+			ok = true
+			return
+		}
+	}
+	ok = o.Contains(from)
+	return
+}
+
+// Bounds returns the corners of the box that contains the mesh
+func (o *ObstacleMesh) Bounds() (min, max Position) {
+	min, max = o.min, o.max
+	return
+}
+
+// intersectTriangle returns where, from 0 to 1, the segment crosses the triangle (Möller–Trumbore)
+func intersectTriangle(from, to Position, tr Triangle) (t float64, ok bool) {
+	const epsilon = 1e-12
+	d := to.Sub(from)
+	e1, e2 := tr[1].Sub(tr[0]), tr[2].Sub(tr[0])
+	p := d.Cross(e2)
+	det := e1.Dot(p)
+	if math.Abs(det) < epsilon {
+		return
+	}
+	s := from.Sub(tr[0])
+	u := s.Dot(p) / det
+	if u < 0 || u > 1 {
+		return
+	}
+	q := s.Cross(e1)
+	v := d.Dot(q) / det
+	if v < 0 || u+v > 1 {
+		return
+	}
+	t = e2.Dot(q) / det
+	ok = t >= 0 && t <= 1
+	return
+}
@@ -0,0 +1,42 @@
+package positioner
+
+import "math"
+
+// NewObstacleSphere creates a new ObstacleSphere
+func NewObstacleSphere(center Position, radius float64) (o *ObstacleSphere) {
+	o = &ObstacleSphere{center: center, radius: radius}
+	return
+}
+
+// ObstacleSphere is an implementation of Obstacle
+// A sphere, like a round rock
+type ObstacleSphere struct {
+	// center of the sphere
+	center Position
+	// radius of the sphere in meters
+	radius float64
+}
+
+// Contains returns true if the position is inside the sphere
+func (o *ObstacleSphere) Contains(position Position) (ok bool) {
+	ok = position.Sub(o.center).Length() <= o.radius
+	return
+}
+
+// Intersects returns true if the closest point of the segment to the center is inside the sphere
+func (o *ObstacleSphere) Intersects(from, to Position) (ok bool) {
+	d := to.Sub(from)
+	t := 0.0
+	if dd := d.Dot(d); dd > 0 {
+		t = math.Max(0, math.Min(1, o.center.Sub(from).Dot(d)/dd))
+	}
+	ok = o.Contains(from.Add(d.Scale(t)))
+	return
+}
+
+// Bounds returns the corners of the box that contains the sphere
+func (o *ObstacleSphere) Bounds() (min, max Position) {
+	r := Position{X: o.radius, Y: o.radius, Z: o.radius}
+	min, max = o.center.Sub(r), o.center.Add(r)
+	return
+}
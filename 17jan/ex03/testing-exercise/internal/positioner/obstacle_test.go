@@ -0,0 +1,146 @@
+package positioner_test
+
+import (
+	"strings"
+	"testdoubles/internal/positioner"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// cube is an OBJ file of a closed cube from (10, 10, 10) to (20, 20, 20)
+const cube = `# cube
+v 10 10 10
+v 20 10 10
+v 20 20 10
+v 10 20 10
+v 10 10 20
+v 20 10 20
+v 20 20 20
+v 10 20 20
+f 1 4 3 2
+f 5 6 7 8
+f 1 2 6 5
+f 2 3 7 6
+f 3 4 8 7
+f 4 1 5 8
+`
+
+// Tests for the implementations of Obstacle
+func TestObstacle(t *testing.T) {
+	mesh, err := positioner.LoadObstacleMesh(strings.NewReader(cube))
+	require.NoError(t, err)
+
+	type testCase struct {
+		name     string
+		obstacle positioner.Obstacle
+		inside   positioner.Position
+		outside  positioner.Position
+		through  [2]positioner.Position
+		beside   [2]positioner.Position
+		min, max positioner.Position
+	}
+
+	cases := []testCase{
+		// case 1: sphere
+		{
+			name:     "sphere",
+			obstacle: positioner.NewObstacleSphere(positioner.Position{X: 15, Y: 15, Z: 15}, 5),
+			inside:   positioner.Position{X: 15, Y: 15, Z: 19},
+			outside:  positioner.Position{X: 19, Y: 19, Z: 19},
+			through:  [2]positioner.Position{{X: 0, Y: 15, Z: 15}, {X: 30, Y: 15, Z: 15}},
+			beside:   [2]positioner.Position{{X: 0, Y: 21, Z: 15}, {X: 30, Y: 21, Z: 15}},
+			min:      positioner.Position{X: 10, Y: 10, Z: 10},
+			max:      positioner.Position{X: 20, Y: 20, Z: 20},
+		},
+		// case 2: box
+		{
+			name:     "box",
+			obstacle: positioner.NewObstacleBox(positioner.Position{X: 10, Y: 10, Z: 10}, positioner.Position{X: 20, Y: 20, Z: 20}),
+			inside:   positioner.Position{X: 19, Y: 19, Z: 19},
+			outside:  positioner.Position{X: 21, Y: 15, Z: 15},
+			through:  [2]positioner.Position{{X: 0, Y: 0, Z: 0}, {X: 30, Y: 30, Z: 30}},
+			beside:   [2]positioner.Position{{X: 0, Y: 21, Z: 15}, {X: 30, Y: 21, Z: 15}},
+			min:      positioner.Position{X: 10, Y: 10, Z: 10},
+			max:      positioner.Position{X: 20, Y: 20, Z: 20},
+		},
+		// case 3: mesh
+		{
+			name:     "mesh",
+			obstacle: mesh,
+			inside:   positioner.Position{X: 12, Y: 17, Z: 13},
+			outside:  positioner.Position{X: 25, Y: 15, Z: 15},
+			through:  [2]positioner.Position{{X: 15, Y: 15, Z: 0}, {X: 15, Y: 15, Z: 30}},
+			beside:   [2]positioner.Position{{X: 0, Y: 21, Z: 15}, {X: 30, Y: 21, Z: 15}},
+			min:      positioner.Position{X: 10, Y: 10, Z: 10},
+			max:      positioner.Position{X: 20, Y: 20, Z: 20},
+		},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// act
+			min, max := c.obstacle.Bounds()
+
+			// assert
+			require.True(t, c.obstacle.Contains(c.inside))
+			require.False(t, c.obstacle.Contains(c.outside))
+			require.True(t, c.obstacle.Intersects(c.through[0], c.through[1]))
+			require.False(t, c.obstacle.Intersects(c.beside[0], c.beside[1]))
+			require.Equal(t, c.min, min)
+			require.Equal(t, c.max, max)
+		})
+	}
+}
+
+// Tests for LoadObstacleMesh
+func TestLoadObstacleMesh(t *testing.T) {
+	type output struct {
+		err    error
+		errMsg string
+	}
+	type testCase struct {
+		name   string
+		input  string
+		output output
+	}
+
+	cases := []testCase{
+		// case 1: faces with texture and normal indexes, and negative indexes
+		{
+			name:   "indexes with slashes and negative indexes",
+			input:  "v 0 0 0\nv 1 0 0\nv 0 1 0\nvn 0 0 1\nf 1/1/1 2//1 -1\n",
+			output: output{},
+		},
+		// case 2: vertex with missing coordinates
+		{
+			name:   "vertex with missing coordinates",
+			input:  "v 0 0 0\nv 1 0\n",
+			output: output{err: positioner.ErrMeshInvalid, errMsg: "invalid mesh: line 2: a vertex needs 3 coordinates"},
+		},
+		// case 3: face with a vertex that does not exist
+		{
+			name:   "face with a vertex that does not exist",
+			input:  "v 0 0 0\nv 1 0 0\nv 0 1 0\n\nf 1 2 4\n",
+			output: output{err: positioner.ErrMeshInvalid, errMsg: "invalid mesh: line 5: vertex 4 does not exist"},
+		},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// act
+			o, err := positioner.LoadObstacleMesh(strings.NewReader(c.input))
+
+			// assert
+			if c.output.err != nil {
+				require.ErrorIs(t, err, c.output.err)
+				require.EqualError(t, err, c.output.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, o)
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package positioner
 
+import (
+	"errors"
+	"fmt"
+)
+
 // Position is a struct that represents a position
 type Position struct {
 	// x coordinate
@@ -10,8 +15,67 @@ type Position struct {
 	Z float64
 }
 
+// Clone returns a copy of the position (nil if the position is nil)
+// - a subject keeps and hands out copies, so nobody changes its position behind its back
+func (p *Position) Clone() (clone *Position) {
+	if p == nil {
+		return
+	}
+	c := *p
+	clone = &c
+	return
+}
+
+// Velocity is a vector that represents a velocity (in m/s along each axis)
+// - it is a Position, so both can be added and compared
+type Velocity = Position
+
 // Positioner is an interface that represents a positioner
 type Positioner interface {
 	// GetLinearDistance returns the linear distance between 2 positions (in meters)
 	GetLinearDistance(from, to *Position) (linearDistance float64)
 }
+
+// ErrMetricUnknown is returned when there is no positioner for a metric
+var ErrMetricUnknown = errors.New("unknown metric")
+
+// Metrics of the positioners
+const (
+	// MetricEuclidean is the straight line distance (PositionerDefault)
+	MetricEuclidean = "euclidean"
+	// MetricManhattan is the sum of the distances along each axis (PositionerManhattan)
+	MetricManhattan = "manhattan"
+	// MetricChebyshev is the longest of the distances along each axis (PositionerChebyshev)
+	MetricChebyshev = "chebyshev"
+	// MetricWeighted is the euclidean distance with a cost for the vertical distance (PositionerWeighted)
+	MetricWeighted = "weighted"
+	// MetricHaversine is the great-circle distance between geographic positions (PositionerHaversine)
+	MetricHaversine = "haversine"
+)
+
+// ConfigPositioner is the configuration to pick a Positioner
+type ConfigPositioner struct {
+	// Metric is the distance the positioner returns (default: euclidean)
+	Metric string
+	// VerticalCost multiplies the distance along Z of the weighted metric (default: 1)
+	VerticalCost float64
+}
+
+// NewPositioner creates the Positioner of a metric
+func NewPositioner(cfg ConfigPositioner) (p Positioner, err error) {
+	switch cfg.Metric {
+	case "", MetricEuclidean:
+		p = NewPositionerDefault()
+	case MetricManhattan:
+		p = NewPositionerManhattan()
+	case MetricChebyshev:
+		p = NewPositionerChebyshev()
+	case MetricWeighted:
+		p = NewPositionerWeighted(ConfigPositionerWeighted{VerticalCost: cfg.VerticalCost})
+	case MetricHaversine:
+		p = NewPositionerHaversine()
+	default:
+		err = fmt.Errorf("%w: %s", ErrMetricUnknown, cfg.Metric)
+	}
+	return
+}
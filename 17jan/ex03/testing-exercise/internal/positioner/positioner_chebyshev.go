@@ -0,0 +1,20 @@
+package positioner
+
+import "math"
+
+// NewPositionerChebyshev creates a new PositionerChebyshev
+func NewPositionerChebyshev() (p *PositionerChebyshev) {
+	p = &PositionerChebyshev{}
+	return
+}
+
+// PositionerChebyshev is an implementation of Positioner
+// The distance between 2 positions is the longest of the distances along each axis
+type PositionerChebyshev struct{}
+
+// GetLinearDistance returns the chebyshev distance between 2 positions (in meters)
+func (p *PositionerChebyshev) GetLinearDistance(from, to *Position) (linearDistance float64) {
+	d := to.Sub(*from)
+	linearDistance = math.Max(math.Abs(d.X), math.Max(math.Abs(d.Y), math.Abs(d.Z)))
+	return
+}
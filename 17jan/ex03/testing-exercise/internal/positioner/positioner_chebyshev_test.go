@@ -0,0 +1,54 @@
+package positioner_test
+
+import (
+	"testdoubles/internal/positioner"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for PositionerChebyshev
+func TestPositionerChebyshev_GetLinearDistance(t *testing.T) {
+	type input struct{ from, to *positioner.Position }
+	type output struct{ linearDistance float64 }
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	cases := []testCase{
+		// case 1: same position
+		{
+			name:   "same position",
+			input:  input{from: &positioner.Position{X: 1, Y: 2, Z: 3}, to: &positioner.Position{X: 1, Y: 2, Z: 3}},
+			output: output{linearDistance: 0},
+		},
+		// case 2: along one axis it is the euclidean distance
+		{
+			name:   "along one axis",
+			input:  input{from: &positioner.Position{Z: 4}, to: &positioner.Position{Z: -3}},
+			output: output{linearDistance: 7},
+		},
+		// case 3: the longest of the distances along each axis
+		{
+			name:   "along every axis",
+			input:  input{from: &positioner.Position{X: 1, Y: -2, Z: 3}, to: &positioner.Position{X: -2, Y: 4, Z: 8}},
+			output: output{linearDistance: 6},
+		},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// arrange
+			impl := positioner.NewPositionerChebyshev()
+
+			// act
+			linearDistance := impl.GetLinearDistance(c.input.from, c.input.to)
+
+			// assert
+			require.Equal(t, c.output.linearDistance, linearDistance)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package positioner
+
+import "math"
+
+// earthRadius is the mean radius of the Earth (in meters)
+const earthRadius = 6371008.8
+
+// NewPositionerHaversine creates a new PositionerHaversine
+func NewPositionerHaversine() (p *PositionerHaversine) {
+	p = &PositionerHaversine{}
+	return
+}
+
+// PositionerHaversine is an implementation of Positioner for geographic positions, like the ones of GPS tags
+// The positions hold the latitude in X and the longitude in Y (in degrees), and the depth in Z (in meters, down from the surface)
+// - the distance along the surface is the great-circle distance (haversine formula), at the mean depth of both positions
+// - the distance along the surface and the difference of depth are combined as the sides of a right triangle
+type PositionerHaversine struct{}
+
+// GetLinearDistance returns the distance between 2 geographic positions (in meters)
+func (p *PositionerHaversine) GetLinearDistance(from, to *Position) (linearDistance float64) {
+	lat1, lat2 := radians(from.X), radians(to.X)
+	dLat, dLon := lat2-lat1, radians(to.Y-from.Y)
+
+	// central angle between both positions
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	angle := 2 * math.Asin(math.Sqrt(math.Min(h, 1)))
+
+	// the deeper, the shorter the arc
+	arc := angle * (earthRadius - (from.Z+to.Z)/2)
+	linearDistance = math.Hypot(arc, to.Z-from.Z)
+	return
+}
+
+// radians converts an angle in degrees to radians
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,87 @@
+package positioner_test
+
+import (
+	"math"
+	"testdoubles/internal/positioner"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for PositionerHaversine
+func TestPositionerHaversine_GetLinearDistance(t *testing.T) {
+	type input struct{ from, to *positioner.Position }
+	type output struct{ linearDistance float64 }
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	// degree is the length of 1 degree along a meridian at the surface (in meters)
+	degree := 6371008.8 * math.Pi / 180
+
+	cases := []testCase{
+		// case 1: same position
+		{
+			name:   "same position",
+			input:  input{from: &positioner.Position{X: 38.7, Y: -9.1, Z: 20}, to: &positioner.Position{X: 38.7, Y: -9.1, Z: 20}},
+			output: output{linearDistance: 0},
+		},
+		// case 2: 1 degree of latitude at the surface
+		{
+			name:   "1 degree of latitude",
+			input:  input{from: &positioner.Position{X: 10, Y: 30}, to: &positioner.Position{X: 11, Y: 30}},
+			output: output{linearDistance: degree},
+		},
+		// case 3: half the equator
+		{
+			name:   "half the equator",
+			input:  input{from: &positioner.Position{Y: -90}, to: &positioner.Position{Y: 90}},
+			output: output{linearDistance: 180 * degree},
+		},
+		// case 4: straight down, the distance is the difference of depth
+		{
+			name:   "straight down",
+			input:  input{from: &positioner.Position{X: -33, Y: 151, Z: 5}, to: &positioner.Position{X: -33, Y: 151, Z: 125}},
+			output: output{linearDistance: 120},
+		},
+		// case 5: the arc is shorter at depth
+		{
+			name:   "1 degree of latitude at depth",
+			input:  input{from: &positioner.Position{Z: 100}, to: &positioner.Position{X: 1, Z: 100}},
+			output: output{linearDistance: degree * (6371008.8 - 100) / 6371008.8},
+		},
+		// case 6: across the date line
+		{
+			name:   "across the date line",
+			input:  input{from: &positioner.Position{Y: 179.5}, to: &positioner.Position{Y: -179.5}},
+			output: output{linearDistance: degree},
+		},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// arrange
+			impl := positioner.NewPositionerHaversine()
+
+			// act
+			linearDistance := impl.GetLinearDistance(c.input.from, c.input.to)
+
+			// assert
+			require.InDelta(t, c.output.linearDistance, linearDistance, 1e-6)
+		})
+	}
+
+	t.Run("london to paris", func(t *testing.T) {
+		// arrange
+		impl := positioner.NewPositionerHaversine()
+
+		// act
+		linearDistance := impl.GetLinearDistance(&positioner.Position{X: 51.5074, Y: -0.1278}, &positioner.Position{X: 48.8566, Y: 2.3522})
+
+		// assert
+		require.InDelta(t, 343.6e3, linearDistance, 0.1e3)
+	})
+}
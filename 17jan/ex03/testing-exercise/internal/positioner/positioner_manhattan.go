@@ -0,0 +1,20 @@
+package positioner
+
+import "math"
+
+// NewPositionerManhattan creates a new PositionerManhattan
+func NewPositionerManhattan() (p *PositionerManhattan) {
+	p = &PositionerManhattan{}
+	return
+}
+
+// PositionerManhattan is an implementation of Positioner
+// The distance between 2 positions is the sum of the distances along each axis (taxicab distance)
+type PositionerManhattan struct{}
+
+// GetLinearDistance returns the manhattan distance between 2 positions (in meters)
+func (p *PositionerManhattan) GetLinearDistance(from, to *Position) (linearDistance float64) {
+	d := to.Sub(*from)
+	linearDistance = math.Abs(d.X) + math.Abs(d.Y) + math.Abs(d.Z)
+	return
+}
@@ -0,0 +1,246 @@
+package positioner
+
+import (
+	"container/heap"
+	"math"
+)
+
+// defaultCellSize is the default size of each side of the cells of the grid (in meters)
+const defaultCellSize = 10.0
+
+// ConfigPositionerObstacles is the configuration for PositionerObstacles
+type ConfigPositionerObstacles struct {
+	// Arena is the space covered by the grid of the paths (default: the default arena)
+	Arena *Arena
+	// Obstacles are the obstacles in the arena
+	Obstacles []Obstacle
+	// CellSize is the size of each side of the cells of the grid in meters (default: 10)
+	CellSize float64
+}
+
+// NewPositionerObstacles creates a new PositionerObstacles
+func NewPositionerObstacles(cfg ConfigPositionerObstacles) (p *PositionerObstacles) {
+	// default config
+	arena := cfg.Arena
+	if arena == nil {
+		arena = NewArenaDefault()
+	}
+	cellSize := defaultCellSize
+	if cfg.CellSize > 0 {
+		cellSize = cfg.CellSize
+	}
+
+	p = &PositionerObstacles{
+		obstacles: cfg.Obstacles,
+		origin:    arena.Min(),
+		cellSize:  cellSize,
+	}
+	size := arena.Max().Sub(arena.Min())
+	for i, s := range []float64{size.X, size.Y, size.Z} {
+		p.cells[i] = int(math.Max(1, math.Ceil(s/cellSize)))
+	}
+
+	// a cell is blocked if its center is inside an obstacle
+	p.blocked = make([]bool, p.cells[0]*p.cells[1]*p.cells[2])
+	for i := range p.blocked {
+		center := p.center(i)
+		for _, o := range p.obstacles {
+			if o.Contains(center) {
+				p.blocked[i] = true
+				break
+			}
+		}
+	}
+	return
+}
+
+// PositionerObstacles is an implementation of Positioner (and Router and LineOfSight)
+// The distance between 2 positions is the length of the shortest path around the obstacles
+// - the path is found with A* over a grid of cells (voxels) that covers the arena, and then straightened
+// - if there is no path, the distance is infinite
+type PositionerObstacles struct {
+	// obstacles in the arena
+	obstacles []Obstacle
+	// origin is the corner of the grid
+	origin Position
+	// cellSize is the size of each side of the cells in meters
+	cellSize float64
+	// cells is the number of cells on each axis
+	cells [3]int
+	// blocked is true for the cells inside an obstacle
+	blocked []bool
+}
+
+// GetLinearDistance returns the length of the shortest path between 2 positions around the obstacles (in meters)
+func (p *PositionerObstacles) GetLinearDistance(from, to *Position) (linearDistance float64) {
+	path, ok := p.Path(from, to)
+	if !ok {
+		linearDistance = math.Inf(1)
+		return
+	}
+	for i := 1; i < len(path); i++ {
+		linearDistance += (path[i].Sub(path[i-1])).Length()
+	}
+	return
+}
+
+// HasLineOfSight returns true if no obstacle is between 2 positions
+func (p *PositionerObstacles) HasLineOfSight(from, to *Position) (ok bool) {
+	for _, o := range p.obstacles {
+		if o.Intersects(*from, *to) {
+			return
+		}
+	}
+	ok = true
+	return
+}
+
+// Path returns the positions to go through from one position to another around the obstacles, both included
+func (p *PositionerObstacles) Path(from, to *Position) (path []Position, ok bool) {
+	if p.HasLineOfSight(from, to) {
+		path, ok = []Position{*from, *to}, true
+		return
+	}
+
+	cells, ok := p.search(p.cellOf(*from), p.cellOf(*to))
+	if !ok {
+		return
+	}
+	// the path goes through the centers of the cells between the start and the goal
+	path = append(path, *from)
+	for _, c := range cells[1 : len(cells)-1] {
+		path = append(path, p.center(c))
+	}
+	path = append(path, *to)
+	path = p.straighten(path)
+	return
+}
+
+// straighten removes the positions of a path that can be skipped with a straight line
+func (p *PositionerObstacles) straighten(path []Position) (straight []Position) {
+	straight = append(straight, path[0])
+	for i := 0; i < len(path)-1; {
+		// - go as far as it can be seen
+		j := len(path) - 1
+		for j > i+1 && !p.HasLineOfSight(&path[i], &path[j]) {
+			j--
+		}
+		straight = append(straight, path[j])
+		i = j
+	}
+	return
+}
+
+// search returns the cells of the shortest path from one cell to another (A*), both included
+func (p *PositionerObstacles) search(start, goal int) (path []int, ok bool) {
+	cost := map[int]float64{start: 0}
+	previous := map[int]int{}
+	open := &cellQueue{{cell: start, priority: p.estimate(start, goal)}}
+	for open.Len() > 0 {
+		current := heap.Pop(open).(cellItem).cell
+		if current == goal {
+			for c := goal; c != start; c = previous[c] {
+				path = append([]int{c}, path...)
+			}
+			path = append([]int{start}, path...)
+			ok = true
+			return
+		}
+
+		for _, next := range p.neighbors(current) {
+			if p.blocked[next] && next != goal {
+				continue
+			}
+			// - the move must not cut the corner of an obstacle
+			a, b := p.center(current), p.center(next)
+			if next != goal && !p.HasLineOfSight(&a, &b) {
+				continue
+			}
+			c := cost[current] + p.estimate(current, next)
+			if known, seen := cost[next]; seen && known <= c {
+				continue
+			}
+			cost[next], previous[next] = c, current
+			heap.Push(open, cellItem{cell: next, priority: c + p.estimate(next, goal)})
+		}
+	}
+	return
+}
+
+// neighbors returns the cells around a cell (up to 26)
+func (p *PositionerObstacles) neighbors(cell int) (neighbors []int) {
+	x, y, z := p.coordinates(cell)
+	for dz := -1; dz <= 1; dz++ {
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				nx, ny, nz := x+dx, y+dy, z+dz
+				if (dx == 0 && dy == 0 && dz == 0) ||
+					nx < 0 || ny < 0 || nz < 0 ||
+					nx >= p.cells[0] || ny >= p.cells[1] || nz >= p.cells[2] {
+					continue
+				}
+				neighbors = append(neighbors, p.index(nx, ny, nz))
+			}
+		}
+	}
+	return
+}
+
+// estimate returns the straight distance between the centers of 2 cells
+func (p *PositionerObstacles) estimate(a, b int) float64 {
+	return p.center(a).Sub(p.center(b)).Length()
+}
+
+// cellOf returns the cell of a position (positions outside the grid go to the closest cell)
+func (p *PositionerObstacles) cellOf(position Position) (cell int) {
+	var c [3]int
+	for i, v := range []float64{position.X - p.origin.X, position.Y - p.origin.Y, position.Z - p.origin.Z} {
+		c[i] = int(math.Max(0, math.Min(float64(p.cells[i]-1), math.Floor(v/p.cellSize))))
+	}
+	cell = p.index(c[0], c[1], c[2])
+	return
+}
+
+// center returns the position of the center of a cell
+func (p *PositionerObstacles) center(cell int) (center Position) {
+	x, y, z := p.coordinates(cell)
+	center = Position{
+		X: p.origin.X + (float64(x)+0.5)*p.cellSize,
+		Y: p.origin.Y + (float64(y)+0.5)*p.cellSize,
+		Z: p.origin.Z + (float64(z)+0.5)*p.cellSize,
+	}
+	return
+}
+
+// index returns the cell at the coordinates of the grid
+func (p *PositionerObstacles) index(x, y, z int) int {
+	return x + p.cells[0]*(y+p.cells[1]*z)
+}
+
+// coordinates returns the coordinates of a cell in the grid
+func (p *PositionerObstacles) coordinates(cell int) (x, y, z int) {
+	x = cell % p.cells[0]
+	y = (cell / p.cells[0]) % p.cells[1]
+	z = cell / (p.cells[0] * p.cells[1])
+	return
+}
+
+// cellItem is a cell to visit by the search, with its priority (the lower the sooner)
+type cellItem struct {
+	cell     int
+	priority float64
+}
+
+// cellQueue is a priority queue of cells (implements heap.Interface)
+type cellQueue []cellItem
+
+func (q cellQueue) Len() int            { return len(q) }
+func (q cellQueue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q cellQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *cellQueue) Push(x interface{}) { *q = append(*q, x.(cellItem)) }
+func (q *cellQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,79 @@
+package positioner_test
+
+import (
+	"math"
+	"testdoubles/internal/positioner"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for PositionerObstacles
+func TestPositionerObstacles(t *testing.T) {
+	// a wall in the middle of a 100x100x10 arena, with a gap at the top of Y
+	arena := positioner.NewArena(positioner.ConfigArena{
+		X: positioner.Bounds{Min: 0, Max: 100},
+		Y: positioner.Bounds{Min: 0, Max: 100},
+		Z: positioner.Bounds{Min: 0, Max: 10},
+	})
+	wall := positioner.NewObstacleBox(positioner.Position{X: 45, Y: -10, Z: -10}, positioner.Position{X: 55, Y: 80, Z: 20})
+	ps := positioner.NewPositionerObstacles(positioner.ConfigPositionerObstacles{
+		Arena:     arena,
+		Obstacles: []positioner.Obstacle{wall},
+		CellSize:  5,
+	})
+
+	t.Run("line of sight - nothing between", func(t *testing.T) {
+		// arrange
+		from, to := &positioner.Position{X: 10, Y: 90, Z: 5}, &positioner.Position{X: 90, Y: 90, Z: 5}
+
+		// act
+		ok := ps.HasLineOfSight(from, to)
+		distance := ps.GetLinearDistance(from, to)
+
+		// assert
+		require.True(t, ok)
+		require.InDelta(t, 80, distance, 1e-9)
+	})
+
+	t.Run("no line of sight - the path goes around the wall", func(t *testing.T) {
+		// arrange
+		from, to := &positioner.Position{X: 10, Y: 10, Z: 5}, &positioner.Position{X: 90, Y: 10, Z: 5}
+
+		// act
+		ok := ps.HasLineOfSight(from, to)
+		path, found := ps.Path(from, to)
+		distance := ps.GetLinearDistance(from, to)
+
+		// assert
+		require.False(t, ok)
+		require.True(t, found)
+		require.Equal(t, *from, path[0])
+		require.Equal(t, *to, path[len(path)-1])
+		for i := 1; i < len(path); i++ {
+			require.True(t, ps.HasLineOfSight(&path[i-1], &path[i]))
+		}
+		// - at least over the corners of the wall, and a bit more as the path keeps away from them by the cells
+		shortest := 2 * math.Hypot(35, 70)
+		require.GreaterOrEqual(t, distance, shortest)
+		require.Less(t, distance, shortest*1.2)
+	})
+
+	t.Run("no path - the prey is walled in", func(t *testing.T) {
+		// arrange
+		cage := positioner.NewPositionerObstacles(positioner.ConfigPositionerObstacles{
+			Arena:     arena,
+			Obstacles: []positioner.Obstacle{positioner.NewObstacleBox(positioner.Position{X: 45, Y: -10, Z: -10}, positioner.Position{X: 55, Y: 110, Z: 20})},
+			CellSize:  5,
+		})
+		from, to := &positioner.Position{X: 10, Y: 10, Z: 5}, &positioner.Position{X: 90, Y: 10, Z: 5}
+
+		// act
+		_, found := cage.Path(from, to)
+		distance := cage.GetLinearDistance(from, to)
+
+		// assert
+		require.False(t, found)
+		require.True(t, math.IsInf(distance, 1))
+	})
+}
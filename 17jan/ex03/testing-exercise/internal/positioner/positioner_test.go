@@ -0,0 +1,83 @@
+package positioner_test
+
+import (
+	"testdoubles/internal/positioner"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for NewPositioner
+func TestNewPositioner(t *testing.T) {
+	from, to := &positioner.Position{}, &positioner.Position{X: 3, Y: 4, Z: 12}
+
+	t.Run("each metric", func(t *testing.T) {
+		expected := map[string]float64{
+			"":                         13,
+			positioner.MetricEuclidean: 13,
+			positioner.MetricManhattan: 19,
+			positioner.MetricChebyshev: 12,
+			positioner.MetricWeighted:  13,
+		}
+		for metric, distance := range expected {
+			// act
+			p, err := positioner.NewPositioner(positioner.ConfigPositioner{Metric: metric})
+
+			// assert
+			require.NoError(t, err)
+			require.Equal(t, distance, p.GetLinearDistance(from, to), metric)
+		}
+	})
+
+	t.Run("weighted with a vertical cost", func(t *testing.T) {
+		// act
+		p, err := positioner.NewPositioner(positioner.ConfigPositioner{Metric: positioner.MetricWeighted, VerticalCost: 0.25})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 5.830951894845301, p.GetLinearDistance(from, to))
+	})
+
+	t.Run("haversine", func(t *testing.T) {
+		// act
+		p, err := positioner.NewPositioner(positioner.ConfigPositioner{Metric: positioner.MetricHaversine})
+
+		// assert
+		require.NoError(t, err)
+		require.IsType(t, &positioner.PositionerHaversine{}, p)
+	})
+
+	t.Run("unknown metric", func(t *testing.T) {
+		// act
+		p, err := positioner.NewPositioner(positioner.ConfigPositioner{Metric: "hamming"})
+
+		// assert
+		require.ErrorIs(t, err, positioner.ErrMetricUnknown)
+		require.EqualError(t, err, "unknown metric: hamming")
+		require.Nil(t, p)
+	})
+}
+
+// Tests for Position.Clone
+func TestPosition_Clone(t *testing.T) {
+	t.Run("copy of the position", func(t *testing.T) {
+		// arrange
+		p := &positioner.Position{X: 1, Y: 2, Z: 3}
+
+		// act
+		clone := p.Clone()
+		clone.X = 10
+
+		// assert
+		require.Equal(t, &positioner.Position{X: 1, Y: 2, Z: 3}, p)
+		require.Equal(t, &positioner.Position{X: 10, Y: 2, Z: 3}, clone)
+	})
+
+	t.Run("nil position", func(t *testing.T) {
+		// act
+		var p *positioner.Position
+
+		// assert
+		require.Nil(t, p.Clone())
+	})
+}
@@ -0,0 +1,35 @@
+package positioner
+
+// ConfigPositionerWeighted is the configuration for PositionerWeighted
+type ConfigPositionerWeighted struct {
+	// VerticalCost multiplies the distance along Z, so moving up or down costs more than moving sideways (default: 1)
+	VerticalCost float64
+}
+
+// NewPositionerWeighted creates a new PositionerWeighted
+func NewPositionerWeighted(cfg ConfigPositionerWeighted) (p *PositionerWeighted) {
+	// default config
+	verticalCost := 1.0
+	if cfg.VerticalCost > 0 {
+		verticalCost = cfg.VerticalCost
+	}
+
+	p = &PositionerWeighted{verticalCost: verticalCost}
+	return
+}
+
+// PositionerWeighted is an implementation of Positioner
+// The distance between 2 positions is the euclidean distance with the distance along Z multiplied by a cost
+// - with a cost of 1 it is the euclidean distance
+type PositionerWeighted struct {
+	// verticalCost multiplies the distance along Z
+	verticalCost float64
+}
+
+// GetLinearDistance returns the weighted euclidean distance between 2 positions (in meters)
+func (p *PositionerWeighted) GetLinearDistance(from, to *Position) (linearDistance float64) {
+	d := to.Sub(*from)
+	d.Z *= p.verticalCost
+	linearDistance = d.Length()
+	return
+}
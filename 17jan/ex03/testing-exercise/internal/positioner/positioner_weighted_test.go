@@ -0,0 +1,57 @@
+package positioner_test
+
+import (
+	"testdoubles/internal/positioner"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for PositionerWeighted
+func TestPositionerWeighted_GetLinearDistance(t *testing.T) {
+	type input struct {
+		cfg      positioner.ConfigPositionerWeighted
+		from, to *positioner.Position
+	}
+	type output struct{ linearDistance float64 }
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	cases := []testCase{
+		// case 1: default cost - euclidean distance
+		{
+			name:   "default cost",
+			input:  input{from: &positioner.Position{}, to: &positioner.Position{X: 3, Z: 4}},
+			output: output{linearDistance: 5},
+		},
+		// case 2: sideways the cost does not matter
+		{
+			name:   "sideways",
+			input:  input{cfg: positioner.ConfigPositionerWeighted{VerticalCost: 3}, from: &positioner.Position{}, to: &positioner.Position{X: 3, Y: 4}},
+			output: output{linearDistance: 5},
+		},
+		// case 3: diving costs more
+		{
+			name:   "diving",
+			input:  input{cfg: positioner.ConfigPositionerWeighted{VerticalCost: 2}, from: &positioner.Position{Z: 10}, to: &positioner.Position{X: 6, Z: 6}},
+			output: output{linearDistance: 10},
+		},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// arrange
+			impl := positioner.NewPositionerWeighted(c.input.cfg)
+
+			// act
+			linearDistance := impl.GetLinearDistance(c.input.from, c.input.to)
+
+			// assert
+			require.Equal(t, c.output.linearDistance, linearDistance)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package positioner
+
+import "math"
+
+// Vector math on positions
+// - a Position is also used as a vector: a heading, a velocity, the difference between 2 positions...
+// - every method takes and returns values, so none of them allocates
+
+// Add returns the sum of 2 vectors
+func (p Position) Add(q Position) Position {
+	return Position{X: p.X + q.X, Y: p.Y + q.Y, Z: p.Z + q.Z}
+}
+
+// Sub returns the difference of 2 vectors (the vector that goes from q to p)
+func (p Position) Sub(q Position) Position {
+	return Position{X: p.X - q.X, Y: p.Y - q.Y, Z: p.Z - q.Z}
+}
+
+// Scale returns the vector multiplied by k
+func (p Position) Scale(k float64) Position {
+	return Position{X: p.X * k, Y: p.Y * k, Z: p.Z * k}
+}
+
+// Dot returns the dot product of 2 vectors
+func (p Position) Dot(q Position) float64 {
+	return p.X*q.X + p.Y*q.Y + p.Z*q.Z
+}
+
+// Cross returns the cross product of 2 vectors
+func (p Position) Cross(q Position) Position {
+	return Position{X: p.Y*q.Z - p.Z*q.Y, Y: p.Z*q.X - p.X*q.Z, Z: p.X*q.Y - p.Y*q.X}
+}
+
+// Length returns the length of the vector
+func (p Position) Length() float64 {
+	return math.Sqrt(p.Dot(p))
+}
+
+// Distance returns the distance between 2 positions
+func (p Position) Distance(q Position) float64 {
+	return p.Sub(q).Length()
+}
+
+// Normalize returns the vector with length 1 and the same direction (the zero vector stays zero)
+func (p Position) Normalize() Position {
+	l := p.Length()
+	if l == 0 {
+		return Position{}
+	}
+	return p.Scale(1 / l)
+}
+
+// Lerp returns the position at t along the way from p (t = 0) to q (t = 1)
+func (p Position) Lerp(q Position, t float64) Position {
+	return p.Add(q.Sub(p).Scale(t))
+}
+
+// Rotate returns the vector rotated by a quaternion (q v q*)
+func (p Position) Rotate(q Quaternion) Position {
+	// - v + 2w(u × v) + 2u × (u × v), with u the vector part of the quaternion
+	u := Position{X: q.X, Y: q.Y, Z: q.Z}
+	t := u.Cross(p).Scale(2)
+	return p.Add(t.Scale(q.W)).Add(u.Cross(t))
+}
+
+// Quaternion is a struct that represents a rotation in the 3D space
+// - it must be a unit quaternion to rotate vectors, as the ones returned by NewQuaternion
+type Quaternion struct {
+	// W is the scalar part
+	W float64
+	// X, Y and Z are the vector part
+	X float64
+	Y float64
+	Z float64
+}
+
+// NewQuaternion returns the rotation of angle radians around an axis (right-hand rule)
+// - the zero axis returns the identity
+func NewQuaternion(axis Position, angle float64) (q Quaternion) {
+	axis = axis.Normalize()
+	if axis == (Position{}) {
+		q = Quaternion{W: 1}
+		return
+	}
+	sin, cos := math.Sincos(angle / 2)
+	q = Quaternion{W: cos, X: axis.X * sin, Y: axis.Y * sin, Z: axis.Z * sin}
+	return
+}
+
+// Mul returns the rotation of r followed by the rotation of q
+func (q Quaternion) Mul(r Quaternion) Quaternion {
+	return Quaternion{
+		W: q.W*r.W - q.X*r.X - q.Y*r.Y - q.Z*r.Z,
+		X: q.W*r.X + q.X*r.W + q.Y*r.Z - q.Z*r.Y,
+		Y: q.W*r.Y - q.X*r.Z + q.Y*r.W + q.Z*r.X,
+		Z: q.W*r.Z + q.X*r.Y - q.Y*r.X + q.Z*r.W,
+	}
+}
+
+// Conjugate returns the inverse rotation of a unit quaternion
+func (q Quaternion) Conjugate() Quaternion {
+	return Quaternion{W: q.W, X: -q.X, Y: -q.Y, Z: -q.Z}
+}
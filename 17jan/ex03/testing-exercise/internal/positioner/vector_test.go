@@ -0,0 +1,127 @@
+package positioner_test
+
+import (
+	"math"
+	"testdoubles/internal/positioner"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for the vector math on Position
+func TestPosition_Vector(t *testing.T) {
+	a := positioner.Position{X: 1, Y: 2, Z: 3}
+	b := positioner.Position{X: 4, Y: -5, Z: 6}
+
+	t.Run("add, sub and scale", func(t *testing.T) {
+		require.Equal(t, positioner.Position{X: 5, Y: -3, Z: 9}, a.Add(b))
+		require.Equal(t, positioner.Position{X: -3, Y: 7, Z: -3}, a.Sub(b))
+		require.Equal(t, positioner.Position{X: 2, Y: 4, Z: 6}, a.Scale(2))
+	})
+
+	t.Run("dot and cross", func(t *testing.T) {
+		require.Equal(t, 12.0, a.Dot(b))
+		require.Equal(t, positioner.Position{X: 27, Y: 6, Z: -13}, a.Cross(b))
+		// - the cross product is perpendicular to both vectors
+		require.Equal(t, 0.0, a.Cross(b).Dot(a))
+		require.Equal(t, 0.0, a.Cross(b).Dot(b))
+	})
+
+	t.Run("length, distance and normalize", func(t *testing.T) {
+		require.Equal(t, 5.0, positioner.Position{X: 3, Y: 4}.Length())
+		require.Equal(t, 5.0, positioner.Position{X: 1, Y: 1, Z: 1}.Distance(positioner.Position{X: 4, Y: 5, Z: 1}))
+		require.Equal(t, positioner.Position{Z: -1}, positioner.Position{Z: -4}.Normalize())
+		require.InDelta(t, 1.0, a.Normalize().Length(), 1e-12)
+		// - the zero vector stays zero
+		require.Equal(t, positioner.Position{}, positioner.Position{}.Normalize())
+	})
+
+	t.Run("lerp", func(t *testing.T) {
+		require.Equal(t, a, a.Lerp(b, 0))
+		require.Equal(t, b, a.Lerp(b, 1))
+		require.Equal(t, positioner.Position{X: 2.5, Y: -1.5, Z: 4.5}, a.Lerp(b, 0.5))
+	})
+}
+
+// Tests for the rotation of a Position by a Quaternion
+func TestPosition_Rotate(t *testing.T) {
+	type input struct {
+		vector positioner.Position
+		q      positioner.Quaternion
+	}
+	type output struct{ vector positioner.Position }
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	cases := []testCase{
+		// case 1: a quarter turn around Z turns X into Y
+		{
+			name:   "quarter turn around Z",
+			input:  input{vector: positioner.Position{X: 1}, q: positioner.NewQuaternion(positioner.Position{Z: 1}, math.Pi/2)},
+			output: output{vector: positioner.Position{Y: 1}},
+		},
+		// case 2: half a turn around X turns Y into -Y
+		{
+			name:   "half turn around X",
+			input:  input{vector: positioner.Position{Y: 2}, q: positioner.NewQuaternion(positioner.Position{X: 5}, math.Pi)},
+			output: output{vector: positioner.Position{Y: -2}},
+		},
+		// case 3: a third of a turn around the diagonal cycles the axes
+		{
+			name:   "third of a turn around the diagonal",
+			input:  input{vector: positioner.Position{X: 1}, q: positioner.NewQuaternion(positioner.Position{X: 1, Y: 1, Z: 1}, 2*math.Pi/3)},
+			output: output{vector: positioner.Position{Y: 1}},
+		},
+		// case 4: 2 quarter turns around Z are half a turn
+		{
+			name: "composed rotations",
+			input: input{vector: positioner.Position{X: 1, Z: 1}, q: positioner.NewQuaternion(positioner.Position{Z: 1}, math.Pi/2).
+				Mul(positioner.NewQuaternion(positioner.Position{Z: 1}, math.Pi/2))},
+			output: output{vector: positioner.Position{X: -1, Z: 1}},
+		},
+		// case 5: the conjugate undoes the rotation
+		{
+			name:   "conjugate",
+			input:  input{vector: positioner.Position{Y: 1}, q: positioner.NewQuaternion(positioner.Position{Z: 1}, math.Pi/2).Conjugate()},
+			output: output{vector: positioner.Position{X: 1}},
+		},
+		// case 6: the zero axis is the identity
+		{
+			name:   "zero axis",
+			input:  input{vector: positioner.Position{X: 1, Y: 2, Z: 3}, q: positioner.NewQuaternion(positioner.Position{}, math.Pi)},
+			output: output{vector: positioner.Position{X: 1, Y: 2, Z: 3}},
+		},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// act
+			vector := c.input.vector.Rotate(c.input.q)
+
+			// assert
+			require.InDelta(t, c.output.vector.X, vector.X, 1e-9)
+			require.InDelta(t, c.output.vector.Y, vector.Y, 1e-9)
+			require.InDelta(t, c.output.vector.Z, vector.Z, 1e-9)
+		})
+	}
+}
+
+// Tests that the vector math does not allocate
+func TestPosition_Vector_Allocations(t *testing.T) {
+	a := positioner.Position{X: 1, Y: 2, Z: 3}
+	b := positioner.Position{X: 4, Y: -5, Z: 6}
+	q := positioner.NewQuaternion(positioner.Position{X: 1, Y: 1}, 1)
+	var sink positioner.Position
+
+	allocs := testing.AllocsPerRun(100, func() {
+		sink = a.Add(b).Sub(a).Scale(2).Cross(b).Normalize().Lerp(b, 0.5).Rotate(q.Mul(q.Conjugate()))
+		sink.X += a.Dot(b) + a.Length() + a.Distance(b)
+	})
+
+	require.Equal(t, 0.0, allocs)
+	require.NotZero(t, sink)
+}
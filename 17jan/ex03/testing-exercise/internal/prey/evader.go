@@ -0,0 +1,21 @@
+package prey
+
+import (
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+)
+
+// Evader is an interface that represents how a prey reacts to a hunter
+// It is asked for the heading of the prey on each step of a stepped simulation
+type Evader interface {
+	// Heading returns the direction the prey heads to
+	// - prey: is the current state of the prey
+	// - hunter: is the current state of the hunter
+	Heading(prey, hunter *simulator.Subject) (heading *positioner.Position)
+}
+
+// away returns the unit vector that goes from the hunter to the prey
+func away(prey, hunter *simulator.Subject) (v positioner.Position) {
+	v = prey.Position.Sub(*hunter.Position).Normalize()
+	return
+}
@@ -0,0 +1,23 @@
+package prey
+
+import (
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+)
+
+// NewEvaderFlee creates a new EvaderFlee
+func NewEvaderFlee() (ev *EvaderFlee) {
+	ev = &EvaderFlee{}
+	return
+}
+
+// EvaderFlee is an implementation of Evader
+// The prey flees straight away from the hunter
+type EvaderFlee struct{}
+
+// Heading returns the direction from the hunter to the prey
+func (e *EvaderFlee) Heading(prey, hunter *simulator.Subject) (heading *positioner.Position) {
+	v := away(prey, hunter)
+	heading = &v
+	return
+}
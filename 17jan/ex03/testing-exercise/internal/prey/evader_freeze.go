@@ -0,0 +1,22 @@
+package prey
+
+import (
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+)
+
+// NewEvaderFreeze creates a new EvaderFreeze
+func NewEvaderFreeze() (ev *EvaderFreeze) {
+	ev = &EvaderFreeze{}
+	return
+}
+
+// EvaderFreeze is an implementation of Evader
+// The prey freezes hoping not to be noticed: it does not move at all
+type EvaderFreeze struct{}
+
+// Heading returns no direction, so the prey stays still
+func (e *EvaderFreeze) Heading(prey, hunter *simulator.Subject) (heading *positioner.Position) {
+	heading = &positioner.Position{}
+	return
+}
@@ -0,0 +1,86 @@
+package prey
+
+import (
+	"math"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+)
+
+const (
+	// defaultHideMargin is the default distance from the obstacle at which the prey hides (in meters)
+	defaultHideMargin = 2.0
+	// hideArrivalRadius is the distance to the hiding spot at which the prey stops (in meters)
+	hideArrivalRadius = 0.5
+)
+
+// ConfigEvaderHide is the configuration for EvaderHide
+type ConfigEvaderHide struct {
+	// Obstacles are the obstacles the prey can hide behind
+	Obstacles []positioner.Obstacle
+	// Margin is the distance from the obstacle at which the prey hides in meters (default: 2)
+	Margin float64
+}
+
+// NewEvaderHide creates a new EvaderHide
+func NewEvaderHide(cfg ConfigEvaderHide) (ev *EvaderHide) {
+	// default config
+	margin := defaultHideMargin
+	if cfg.Margin > 0 {
+		margin = cfg.Margin
+	}
+
+	ev = &EvaderHide{obstacles: cfg.Obstacles, margin: margin}
+	return
+}
+
+// EvaderHide is an implementation of Evader
+// The prey swims to the spot behind the closest obstacle, on the opposite side to the hunter, and stays there
+// - the obstacle is taken as the sphere around its bounds
+// - without obstacles, the prey flees straight away from the hunter
+type EvaderHide struct {
+	// obstacles the prey can hide behind
+	obstacles []positioner.Obstacle
+	// distance from the obstacle at which the prey hides in meters
+	margin float64
+}
+
+// Heading returns the direction to the closest hiding spot (no direction once the prey is there)
+func (e *EvaderHide) Heading(prey, hunter *simulator.Subject) (heading *positioner.Position) {
+	spot, ok := e.spot(*prey.Position, *hunter.Position)
+	if !ok {
+		v := away(prey, hunter)
+		heading = &v
+		return
+	}
+
+	v := spot.Sub(*prey.Position)
+	if v.Length() <= hideArrivalRadius {
+		heading = &positioner.Position{}
+		return
+	}
+	v = v.Normalize()
+	heading = &v
+	return
+}
+
+// spot returns the closest hiding spot to the prey
+func (e *EvaderHide) spot(prey, hunter positioner.Position) (spot positioner.Position, ok bool) {
+	closest := math.Inf(1)
+	for _, o := range e.obstacles {
+		min, max := o.Bounds()
+		center := min.Lerp(max, 0.5)
+		radius := max.Distance(min) / 2
+
+		// - behind the obstacle, on the line from the hunter through its center
+		d := center.Sub(hunter).Normalize()
+		if d.Length() == 0 {
+			continue
+		}
+		candidate := center.Add(d.Scale(radius + e.margin))
+
+		if distance := candidate.Distance(prey); distance < closest {
+			closest, spot, ok = distance, candidate, true
+		}
+	}
+	return
+}
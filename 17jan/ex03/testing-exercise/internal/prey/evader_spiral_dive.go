@@ -0,0 +1,65 @@
+package prey
+
+import (
+	"math"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+)
+
+// ConfigEvaderSpiralDive is the configuration for EvaderSpiralDive
+type ConfigEvaderSpiralDive struct {
+	// Bottom is the lowest Z coordinate the prey dives to (in meters)
+	Bottom float64
+	// TurnRate is how fast the prey turns around the spiral (in radians per second)
+	TurnRate float64
+	// DiveAngle is the angle of the dive below the horizontal plane (in radians)
+	DiveAngle float64
+}
+
+// NewEvaderSpiralDive creates a new EvaderSpiralDive
+func NewEvaderSpiralDive(cfg ConfigEvaderSpiralDive) (ev *EvaderSpiralDive) {
+	// default config
+	turnRate := 0.5
+	if cfg.TurnRate != 0 {
+		turnRate = cfg.TurnRate
+	}
+	diveAngle := math.Pi / 6
+	if cfg.DiveAngle > 0 {
+		diveAngle = cfg.DiveAngle
+	}
+
+	ev = &EvaderSpiralDive{bottom: cfg.Bottom, turnRate: turnRate, diveAngle: diveAngle}
+	return
+}
+
+// EvaderSpiralDive is an implementation of Evader
+// The prey dives in a spiral toward the bottom of the Z range, and keeps circling once it gets there
+type EvaderSpiralDive struct {
+	// bottom of the Z range in meters
+	bottom float64
+	// turn rate of the spiral in radians per second
+	turnRate float64
+	// dive angle in radians
+	diveAngle float64
+}
+
+// Heading returns the direction along the spiral at the current time
+func (e *EvaderSpiralDive) Heading(prey, hunter *simulator.Subject) (heading *positioner.Position) {
+	// horizontal direction: the flee direction turned around the vertical axis as time goes by
+	f := away(prey, hunter)
+	start := math.Atan2(f.Y, f.X)
+	angle := start + e.turnRate*prey.Time
+
+	// dive until the bottom is reached
+	dive := e.diveAngle
+	if prey.Position.Z <= e.bottom {
+		dive = 0
+	}
+
+	heading = &positioner.Position{
+		X: math.Cos(angle) * math.Cos(dive),
+		Y: math.Sin(angle) * math.Cos(dive),
+		Z: -math.Sin(dive),
+	}
+	return
+}
@@ -0,0 +1,155 @@
+package prey_test
+
+import (
+	"math"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/prey"
+	"testdoubles/internal/simulator"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for the implementations of Evader
+func TestEvader_Heading(t *testing.T) {
+	type input struct{ prey, hunter *simulator.Subject }
+	type output struct{ heading *positioner.Position }
+	type testCase struct {
+		name   string
+		evader prey.Evader
+		input  input
+		output output
+	}
+
+	// hunter at the origin, prey at (100, 0, 50)
+	hunterSubject := &simulator.Subject{Position: &positioner.Position{X: 0, Y: 0, Z: 50}, Speed: 10}
+	preySubject := func(time float64, z float64) *simulator.Subject {
+		return &simulator.Subject{Position: &positioner.Position{X: 100, Y: 0, Z: z}, Speed: 5, Time: time}
+	}
+
+	cases := []testCase{
+		// case 1: flee straight away
+		{
+			name:   "flee",
+			evader: prey.NewEvaderFlee(),
+			input:  input{prey: preySubject(0, 50), hunter: hunterSubject},
+			output: output{heading: &positioner.Position{X: 1, Y: 0, Z: 0}},
+		},
+		// case 2: zig-zag - first period turns to one side
+		{
+			name:   "zig-zag - first period",
+			evader: prey.NewEvaderZigZag(prey.ConfigEvaderZigZag{Period: 2, Angle: math.Pi / 2}),
+			input:  input{prey: preySubject(1, 50), hunter: hunterSubject},
+			output: output{heading: &positioner.Position{X: 0, Y: 1, Z: 0}},
+		},
+		// case 3: zig-zag - second period turns to the other side
+		{
+			name:   "zig-zag - second period",
+			evader: prey.NewEvaderZigZag(prey.ConfigEvaderZigZag{Period: 2, Angle: math.Pi / 2}),
+			input:  input{prey: preySubject(3, 50), hunter: hunterSubject},
+			output: output{heading: &positioner.Position{X: 0, Y: -1, Z: 0}},
+		},
+		// case 4: spiral dive - above the bottom it dives
+		{
+			name:   "spiral dive - diving",
+			evader: prey.NewEvaderSpiralDive(prey.ConfigEvaderSpiralDive{Bottom: 0, TurnRate: math.Pi / 2, DiveAngle: math.Pi / 6}),
+			input:  input{prey: preySubject(1, 50), hunter: hunterSubject},
+			output: output{heading: &positioner.Position{X: 0, Y: math.Cos(math.Pi / 6), Z: -0.5}},
+		},
+		// case 5: spiral dive - at the bottom it keeps circling
+		{
+			name:   "spiral dive - at the bottom",
+			evader: prey.NewEvaderSpiralDive(prey.ConfigEvaderSpiralDive{Bottom: 50, TurnRate: math.Pi / 2, DiveAngle: math.Pi / 6}),
+			input:  input{prey: preySubject(2, 50), hunter: hunterSubject},
+			output: output{heading: &positioner.Position{X: -1, Y: 0, Z: 0}},
+		},
+		// case 6: freeze
+		{
+			name:   "freeze",
+			evader: prey.NewEvaderFreeze(),
+			input:  input{prey: preySubject(0, 50), hunter: hunterSubject},
+			output: output{heading: &positioner.Position{X: 0, Y: 0, Z: 0}},
+		},
+		// case 7: hide - swims behind the closest obstacle
+		{
+			name:   "hide - to the spot behind the obstacle",
+			evader: prey.NewEvaderHide(prey.ConfigEvaderHide{Obstacles: []positioner.Obstacle{positioner.NewObstacleSphere(positioner.Position{X: 50, Y: 0, Z: 50}, 6)}, Margin: 2}),
+			input:  input{prey: preySubject(0, 50), hunter: hunterSubject},
+			output: output{heading: &positioner.Position{X: -1, Y: 0, Z: 0}},
+		},
+		// case 8: hide - stays at the spot (the sphere around the bounds of the obstacle has a radius of 6*sqrt(3))
+		{
+			name:   "hide - at the spot",
+			evader: prey.NewEvaderHide(prey.ConfigEvaderHide{Obstacles: []positioner.Obstacle{positioner.NewObstacleSphere(positioner.Position{X: 100 - 6*math.Sqrt(3) - 2, Y: 0, Z: 50}, 6)}, Margin: 2}),
+			input:  input{prey: preySubject(0, 50), hunter: hunterSubject},
+			output: output{heading: &positioner.Position{X: 0, Y: 0, Z: 0}},
+		},
+		// case 9: hide - without obstacles it flees
+		{
+			name:   "hide - without obstacles",
+			evader: prey.NewEvaderHide(prey.ConfigEvaderHide{}),
+			input:  input{prey: preySubject(0, 50), hunter: hunterSubject},
+			output: output{heading: &positioner.Position{X: 1, Y: 0, Z: 0}},
+		},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// act
+			heading := c.evader.Heading(c.input.prey, c.input.hunter)
+
+			// assert
+			require.InDelta(t, c.output.heading.X, heading.X, 1e-9)
+			require.InDelta(t, c.output.heading.Y, heading.Y, 1e-9)
+			require.InDelta(t, c.output.heading.Z, heading.Z, 1e-9)
+		})
+	}
+}
+
+// Tests for the evasion of a Tuna in a stepped simulation
+func TestTuna_Heading(t *testing.T) {
+	t.Run("tuna without evader lets the simulator decide", func(t *testing.T) {
+		// arrange
+		impl := prey.NewTuna(5, &positioner.Position{X: 100, Y: 0, Z: 0})
+		hunterSubject := &simulator.Subject{Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
+		preySubject := &simulator.Subject{Position: &positioner.Position{X: 100, Y: 0, Z: 0}}
+
+		// act
+		heading := impl.(prey.Evader).Heading(preySubject, hunterSubject)
+
+		// assert
+		require.Nil(t, heading)
+	})
+
+	t.Run("frozen tuna is caught sooner than a fleeing one", func(t *testing.T) {
+		// arrange
+		sm := simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 100,
+			TimeStep:       0.1,
+			CaptureRadius:  0.5,
+			Positioner:     positioner.NewPositionerDefault(),
+		})
+		hunt := func(ev prey.Evader) (duration float64) {
+			tuna := prey.NewTunaWithConfig(prey.ConfigTuna{
+				Speed:    5,
+				Position: &positioner.Position{X: 100, Y: 0, Z: 0},
+				Evader:   ev,
+			})
+			hunterSubject := &simulator.Subject{Position: &positioner.Position{X: 0, Y: 0, Z: 0}, Speed: 10}
+			preySubject := &simulator.Subject{Position: tuna.GetPosition(), Speed: tuna.GetSpeed(), Navigator: tuna}
+			result, ok := sm.CanCatch(hunterSubject, preySubject)
+			require.True(t, ok)
+			duration = result.Duration
+			return
+		}
+
+		// act
+		frozen := hunt(prey.NewEvaderFreeze())
+		fleeing := hunt(prey.NewEvaderFlee())
+
+		// assert
+		require.InDelta(t, (100-0.5)/10.0, frozen, 1e-9)
+		require.InDelta(t, (100-0.5)/5.0, fleeing, 1e-9)
+	})
+}
@@ -0,0 +1,64 @@
+package prey
+
+import (
+	"math"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+)
+
+// ConfigEvaderZigZag is the configuration for EvaderZigZag
+type ConfigEvaderZigZag struct {
+	// Period is the time the prey keeps each side of the zig-zag (in seconds)
+	Period float64
+	// Angle is how far the prey turns from the flee direction (in radians)
+	Angle float64
+}
+
+// NewEvaderZigZag creates a new EvaderZigZag
+func NewEvaderZigZag(cfg ConfigEvaderZigZag) (ev *EvaderZigZag) {
+	// default config
+	period := 1.0
+	if cfg.Period > 0 {
+		period = cfg.Period
+	}
+	angle := math.Pi / 4
+	if cfg.Angle != 0 {
+		angle = cfg.Angle
+	}
+
+	ev = &EvaderZigZag{period: period, angle: angle}
+	return
+}
+
+// EvaderZigZag is an implementation of Evader
+// The prey flees away from the hunter turning to one side and to the other every period
+type EvaderZigZag struct {
+	// period of each side of the zig-zag in seconds
+	period float64
+	// angle of the turn in radians
+	angle float64
+}
+
+// Heading returns the flee direction turned to the side of the current period
+func (e *EvaderZigZag) Heading(prey, hunter *simulator.Subject) (heading *positioner.Position) {
+	// flee direction
+	f := away(prey, hunter)
+
+	// lateral direction: horizontal and perpendicular to the flee direction
+	l := positioner.Position{X: -f.Y, Y: f.X, Z: 0}.Normalize()
+	if l.Length() == 0 {
+		// fleeing vertically: any horizontal direction is lateral
+		l = positioner.Position{X: 1, Y: 0, Z: 0}
+	}
+
+	// side of the current period
+	side := 1.0
+	if int(prey.Time/e.period)%2 == 1 {
+		side = -1.0
+	}
+
+	cos, sin := math.Cos(e.angle), side*math.Sin(e.angle)
+	h := f.Scale(cos).Add(l.Scale(sin))
+	heading = &h
+	return
+}
@@ -1,6 +1,9 @@
 package prey
 
-import "testdoubles/internal/positioner"
+import (
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+)
 
 // Prey is an interface that represents a prey
 type Prey interface {
@@ -11,4 +14,16 @@ type Prey interface {
 
 	// Configure
 	Configure(speed float64, position *positioner.Position)
+}
+
+// Sprinter is an interface that represents a prey whose speed changes along a hunt
+type Sprinter interface {
+	// GetKinematics returns the acceleration, top speed and stamina of the prey
+	GetKinematics() (kinematics simulator.Kinematics)
+}
+
+// Drifter is an interface that represents a prey the current carries along
+type Drifter interface {
+	// GetDrag returns how much the current carries the prey, from 0 (not at all) to 1 (like the water)
+	GetDrag() (drag float64)
 }
\ No newline at end of file
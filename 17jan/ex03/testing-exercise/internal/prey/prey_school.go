@@ -0,0 +1,239 @@
+package prey
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+)
+
+const (
+	// defaultSchoolNeighborRadius is the default distance in meters at which members of a school align and gather
+	defaultSchoolNeighborRadius = 10.0
+	// defaultSchoolSeparationRadius is the default distance in meters under which members of a school move apart
+	defaultSchoolSeparationRadius = 2.0
+	// defaultSchoolAvoidRadius is the default distance in meters at which members of a school react to a hunter
+	defaultSchoolAvoidRadius = 30.0
+)
+
+// ConfigSchool is the configuration for School
+// - the weights are how much each rule steers the members (default: 1, and 3 for the avoidance)
+type ConfigSchool struct {
+	// Members are the tunas of the school (all of them with a position)
+	Members []*Tuna
+	// NeighborRadius is the distance in meters at which members align and gather (default: 10)
+	NeighborRadius float64
+	// SeparationRadius is the distance in meters under which members move apart (default: 2)
+	SeparationRadius float64
+	// AvoidRadius is the distance in meters at which members react to a hunter (default: 30)
+	AvoidRadius float64
+	// Separation, Alignment, Cohesion and Avoidance are the weights of the rules
+	Separation float64
+	Alignment  float64
+	Cohesion   float64
+	Avoidance  float64
+}
+
+// NewSchool creates a new School
+func NewSchool(config ConfigSchool) *School {
+	// default config
+	def := func(v, d float64) float64 {
+		if v > 0 {
+			return v
+		}
+		return d
+	}
+
+	members := make([]*Tuna, len(config.Members))
+	copy(members, config.Members)
+	return &School{
+		members:          members,
+		neighborRadius:   def(config.NeighborRadius, defaultSchoolNeighborRadius),
+		separationRadius: def(config.SeparationRadius, defaultSchoolSeparationRadius),
+		avoidRadius:      def(config.AvoidRadius, defaultSchoolAvoidRadius),
+		separation:       def(config.Separation, 1),
+		alignment:        def(config.Alignment, 1),
+		cohesion:         def(config.Cohesion, 1),
+		avoidance:        def(config.Avoidance, 3),
+	}
+}
+
+// School is a group of tunas that move together following the boids rules
+// - separation: members move apart from the members too close to them
+// - alignment: members head where their neighbors head
+// - cohesion: members move to the center of their neighbors
+// - avoidance: members flee from the hunters close to them
+// It is also an implementation of the Prey interface: the school as a whole is at the center of its members
+type School struct {
+	// members of the school
+	members []*Tuna
+	// distances of the rules in meters
+	neighborRadius   float64
+	separationRadius float64
+	avoidRadius      float64
+	// weights of the rules
+	separation float64
+	alignment  float64
+	cohesion   float64
+	avoidance  float64
+	// mu guards the members, so the school can be configured or lose members while it is hunted
+	mu sync.RWMutex
+}
+
+// Members returns the tunas left in the school
+func (s *School) Members() (members []*Tuna) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	members = make([]*Tuna, len(s.members))
+	copy(members, s.members)
+	return
+}
+
+// Remove removes a tuna from the school (when it is caught)
+func (s *School) Remove(t *Tuna) (ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, m := range s.members {
+		if m == t {
+			s.members = append(s.members[:i], s.members[i+1:]...)
+			ok = true
+			return
+		}
+	}
+	return
+}
+
+// GetSpeed returns the mean speed of the members of the school
+func (s *School) GetSpeed() (speed float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.members) == 0 {
+		return
+	}
+	for _, m := range s.members {
+		speed += m.GetSpeed()
+	}
+	speed /= float64(len(s.members))
+	return
+}
+
+// GetPosition returns the center of the members of the school (nil if it has no members)
+func (s *School) GetPosition() (position *positioner.Position) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	position = s.center()
+	return
+}
+
+// center returns the center of the members of the school (nil if it has no members)
+// - it is called with the lock held
+func (s *School) center() (position *positioner.Position) {
+	if len(s.members) == 0 {
+		return
+	}
+	position = &positioner.Position{}
+	for _, m := range s.members {
+		p := m.GetPosition()
+		position.X += p.X
+		position.Y += p.Y
+		position.Z += p.Z
+	}
+	n := float64(len(s.members))
+	position.X, position.Y, position.Z = position.X/n, position.Y/n, position.Z/n
+	return
+}
+
+// Configure moves the whole school, so its center is at the position, and sets the speed of every member
+func (s *School) Configure(speed float64, position *positioner.Position) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	center := s.center()
+	for _, m := range s.members {
+		p := *m.GetPosition()
+		if center != nil && position != nil {
+			p = positioner.Position{
+				X: p.X - center.X + position.X,
+				Y: p.Y - center.Y + position.Y,
+				Z: p.Z - center.Z + position.Z,
+			}
+		}
+		m.Configure(speed, &p)
+	}
+}
+
+// Headings returns the direction each member of the school heads to, following the boids rules
+// - members: are the current states of the members of the school
+// - hunters: are the current states of the hunters around
+// A heading is nil if no rule applies to the member, so the simulator decides
+func (s *School) Headings(members, hunters []*simulator.Subject) (headings []*positioner.Position) {
+	headings = make([]*positioner.Position, len(members))
+
+	// the neighbors of each member are found with a spatial index, by their place in the school
+	index := positioner.NewIndexGrid(s.neighborRadius)
+	for j, m := range members {
+		index.Insert(strconv.Itoa(j), *m.Position)
+	}
+
+	for i, self := range members {
+		var separation, alignment, center, avoidance positioner.Position
+		neighbors := 0
+		for _, j := range neighborsOf(index, *self.Position, s.neighborRadius) {
+			if i == j {
+				continue
+			}
+			other := members[j]
+			offset := self.Position.Sub(*other.Position)
+			d := offset.Length()
+			neighbors++
+			// - separation: away from the close members, the closer the stronger
+			if d < s.separationRadius && d > 0 {
+				separation = separation.Add(offset.Scale(1 / (d * d)))
+			}
+			// - alignment: the headings of the neighbors
+			if other.Heading != nil {
+				alignment = alignment.Add(other.Heading.Normalize())
+			}
+			// - cohesion: the center of the neighbors
+			center = center.Add(*other.Position)
+		}
+		// - avoidance: away from the close hunters, the closer the stronger
+		for _, hunter := range hunters {
+			offset := self.Position.Sub(*hunter.Position)
+			d := offset.Length()
+			if d < s.avoidRadius {
+				avoidance = avoidance.Add(offset.Normalize().Scale(1 - d/s.avoidRadius))
+			}
+		}
+
+		var cohesion positioner.Position
+		if neighbors > 0 {
+			cohesion = center.Scale(1 / float64(neighbors)).Sub(*self.Position)
+		}
+		heading := separation.Normalize().Scale(s.separation).
+			Add(alignment.Normalize().Scale(s.alignment)).
+			Add(cohesion.Normalize().Scale(s.cohesion)).
+			Add(avoidance.Scale(s.avoidance))
+		if heading.Length() == 0 {
+			continue
+		}
+		headings[i] = &heading
+	}
+	return
+}
+
+// neighborsOf returns the places in the school of the members within radius of a position
+// - in the order of the school, so the sums of the rules do not depend on the index
+func neighborsOf(index positioner.SpatialIndex, position positioner.Position, radius float64) (places []int) {
+	for _, id := range index.Within(position, radius) {
+		j, _ := strconv.Atoi(id)
+		places = append(places, j)
+	}
+	sort.Ints(places)
+	return
+}
@@ -0,0 +1,151 @@
+package prey_test
+
+import (
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/prey"
+	"testdoubles/internal/simulator"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Unit Tests for School
+func TestSchool_Headings(t *testing.T) {
+	type input struct{ members, hunters []*simulator.Subject }
+	type testCase struct {
+		name   string
+		input  input
+		output []*positioner.Position
+	}
+
+	cases := []testCase{
+		// case 1: a lone member with no hunter around
+		{
+			name: "no rule applies",
+			input: input{
+				members: []*simulator.Subject{{Position: &positioner.Position{}}},
+			},
+			output: []*positioner.Position{nil},
+		},
+		// case 2: a lone member with a hunter close to it
+		{
+			name: "avoidance",
+			input: input{
+				members: []*simulator.Subject{{Position: &positioner.Position{}}},
+				hunters: []*simulator.Subject{{Position: &positioner.Position{X: 10}}},
+			},
+			output: []*positioner.Position{{X: -2}},
+		},
+		// case 3: 2 members, beyond the separation radius, gather
+		{
+			name: "cohesion",
+			input: input{
+				members: []*simulator.Subject{
+					{Position: &positioner.Position{X: 0}},
+					{Position: &positioner.Position{X: 5}},
+				},
+			},
+			output: []*positioner.Position{{X: 1}, {X: -1}},
+		},
+		// case 4: a member heads where its neighbor heads
+		{
+			name: "alignment",
+			input: input{
+				members: []*simulator.Subject{
+					{Position: &positioner.Position{X: 0}},
+					{Position: &positioner.Position{X: 5}, Heading: &positioner.Position{Y: 3}},
+				},
+			},
+			output: []*positioner.Position{{X: 1, Y: 1}, {X: -1}},
+		},
+		// case 5: members beyond the neighbor radius ignore each other
+		{
+			name: "out of the neighbor radius",
+			input: input{
+				members: []*simulator.Subject{
+					{Position: &positioner.Position{X: 0}},
+					{Position: &positioner.Position{X: 50}},
+				},
+			},
+			output: []*positioner.Position{nil, nil},
+		},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// arrange
+			impl := prey.NewSchool(prey.ConfigSchool{})
+
+			// act
+			output := impl.Headings(c.input.members, c.input.hunters)
+
+			// assert
+			require.Len(t, output, len(c.output))
+			for i := range c.output {
+				if c.output[i] == nil {
+					require.Nil(t, output[i])
+					continue
+				}
+				require.NotNil(t, output[i])
+				require.InDelta(t, c.output[i].X, output[i].X, 1e-9)
+				require.InDelta(t, c.output[i].Y, output[i].Y, 1e-9)
+				require.InDelta(t, c.output[i].Z, output[i].Z, 1e-9)
+			}
+		})
+	}
+
+	t.Run("separation", func(t *testing.T) {
+		// arrange
+		impl := prey.NewSchool(prey.ConfigSchool{Separation: 2})
+		members := []*simulator.Subject{
+			{Position: &positioner.Position{X: 0}},
+			{Position: &positioner.Position{X: 1}},
+		}
+
+		// act
+		output := impl.Headings(members, nil)
+
+		// assert
+		require.Equal(t, &positioner.Position{X: -1}, output[0])
+		require.Equal(t, &positioner.Position{X: 1}, output[1])
+	})
+}
+
+func TestSchool_Prey(t *testing.T) {
+	// arrange
+	tuna1 := prey.NewTunaWithConfig(prey.ConfigTuna{Speed: 4, Position: &positioner.Position{X: 0, Y: 0, Z: 0}})
+	tuna2 := prey.NewTunaWithConfig(prey.ConfigTuna{Speed: 8, Position: &positioner.Position{X: 10, Y: 20, Z: 0}})
+	impl := prey.NewSchool(prey.ConfigSchool{Members: []*prey.Tuna{tuna1, tuna2}})
+
+	t.Run("position and speed of the whole school", func(t *testing.T) {
+		// act
+		position := impl.GetPosition()
+		speed := impl.GetSpeed()
+
+		// assert
+		require.Equal(t, &positioner.Position{X: 5, Y: 10, Z: 0}, position)
+		require.Equal(t, 6.0, speed)
+	})
+
+	t.Run("configure moves the whole school", func(t *testing.T) {
+		// act
+		impl.Configure(10, &positioner.Position{X: 105, Y: 10, Z: 50})
+
+		// assert
+		require.Equal(t, &positioner.Position{X: 100, Y: 0, Z: 50}, tuna1.GetPosition())
+		require.Equal(t, &positioner.Position{X: 110, Y: 20, Z: 50}, tuna2.GetPosition())
+		require.Equal(t, 10.0, tuna2.GetSpeed())
+	})
+
+	t.Run("remove a member", func(t *testing.T) {
+		// act
+		ok := impl.Remove(tuna1)
+		again := impl.Remove(tuna1)
+
+		// assert
+		require.True(t, ok)
+		require.False(t, again)
+		require.Equal(t, []*prey.Tuna{tuna2}, impl.Members())
+	})
+}
@@ -0,0 +1,144 @@
+package prey
+
+import (
+	"math/rand"
+	"sync"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+	"testdoubles/internal/species"
+)
+
+// ConfigSpecies is the configuration for Species
+type ConfigSpecies struct {
+	// Profile is what the prey is like: speed, kinematics, size and depth
+	Profile species.Profile
+	// Position of the prey (nil to spawn it at random in the arena, at the depth of the species)
+	Position *positioner.Position
+	// Arena where the prey spawns (nil for the default arena)
+	Arena *positioner.Arena
+	// Src is the source of the random numbers of the speed and the position (nil for the global source of math/rand)
+	Src rand.Source
+	// Evader is how the prey reacts to a hunter (nil to let the simulator decide)
+	Evader Evader
+	// Drag is how much the current carries the prey, from 0 (not at all) to 1 (like the water)
+	Drag float64
+}
+
+// NewSpecies creates a new Species
+// - the top speed of the prey is drawn from the profile, then its position if it has none
+func NewSpecies(config ConfigSpecies) (p *Species) {
+	var rd *rand.Rand
+	if config.Src != nil {
+		rd = rand.New(config.Src)
+	}
+
+	speed := config.Profile.Speed.Sample(rd)
+	position := config.Position.Clone()
+	if position == nil {
+		position = config.Profile.Spawn(config.Arena, rd)
+	}
+
+	p = &Species{
+		profile:    config.Profile,
+		speed:      speed,
+		position:   position,
+		evader:     config.Evader,
+		kinematics: config.Profile.Kinematics(speed),
+		drag:       config.Drag,
+	}
+	return
+}
+
+// Species is an implementation of the Prey interface built from the profile of a species
+// A new species of prey is a new profile in a catalogue, not a new type
+type Species struct {
+	// profile of the species
+	profile species.Profile
+	// speed of the prey in m/s
+	speed float64
+	// position of the prey
+	position *positioner.Position
+	// evader is how the prey reacts to a hunter
+	evader Evader
+	// kinematics: acceleration and stamina of the species
+	kinematics simulator.Kinematics
+	// drag: how much the current carries the prey
+	drag float64
+	// mu guards the speed, the position and the cruise speed, so the prey can be configured while it is hunted
+	mu sync.RWMutex
+}
+
+// GetSpeed returns the speed of the prey
+func (s *Species) GetSpeed() (speed float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	speed = s.speed
+	return
+}
+
+// GetPosition returns a copy of the position of the prey
+func (s *Species) GetPosition() (position *positioner.Position) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	position = s.position.Clone()
+	return
+}
+
+// GetKinematics returns the acceleration, top speed and stamina of the prey
+func (s *Species) GetKinematics() (kinematics simulator.Kinematics) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	kinematics = s.kinematics
+	return
+}
+
+// GetDrag returns how much the current carries the prey
+func (s *Species) GetDrag() (drag float64) {
+	drag = s.drag
+	return
+}
+
+// GetProfile returns the profile of the species of the prey
+func (s *Species) GetProfile() (profile species.Profile) {
+	profile = s.profile
+	return
+}
+
+// Configure configures the prey
+// - the cruise speed follows the new speed
+// - the prey keeps a copy of the position
+func (s *Species) Configure(speed float64, position *positioner.Position) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.speed = speed
+	s.position = position.Clone()
+	s.kinematics.CruiseSpeed = s.profile.Cruise * speed
+}
+
+// Snapshot returns a copy of the state of the prey, read at once
+func (s *Species) Snapshot() (subject *simulator.Subject) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	subject = &simulator.Subject{
+		Position:   s.position.Clone(),
+		Speed:      s.speed,
+		Kinematics: s.kinematics,
+		Drag:       s.drag,
+	}
+	return
+}
+
+// Heading returns the direction the prey heads to when it is hunted
+// - it is nil if the prey has no evader, so the simulator decides
+func (s *Species) Heading(self, hunter *simulator.Subject) (heading *positioner.Position) {
+	if s.evader == nil {
+		return
+	}
+	heading = s.evader.Heading(self, hunter)
+	return
+}
@@ -0,0 +1,58 @@
+package prey_test
+
+import (
+	"math/rand"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/prey"
+	"testdoubles/internal/simulator"
+	"testdoubles/internal/species"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for the Species implementation of the Prey interface
+func TestPreySpecies_NewSpecies(t *testing.T) {
+	t.Run("built from the profile", func(t *testing.T) {
+		// arrange
+		profile := species.Default[species.Sardine]
+
+		// act
+		impl := prey.NewSpecies(prey.ConfigSpecies{Profile: profile, Src: rand.NewSource(1), Drag: 0.8})
+
+		// assert
+		require.GreaterOrEqual(t, impl.GetSpeed(), profile.Speed.Min)
+		require.LessOrEqual(t, impl.GetSpeed(), profile.Speed.Max)
+		require.Equal(t, profile.Kinematics(impl.GetSpeed()), impl.GetKinematics())
+		require.Equal(t, 0.8, impl.GetDrag())
+		// - near the surface, like the sardines
+		require.GreaterOrEqual(t, impl.GetPosition().Z, 500-profile.Depth.Max)
+	})
+
+	t.Run("same seed, same prey", func(t *testing.T) {
+		// act
+		p1 := prey.NewSpecies(prey.ConfigSpecies{Profile: species.Default[species.Squid], Src: rand.NewSource(42)})
+		p2 := prey.NewSpecies(prey.ConfigSpecies{Profile: species.Default[species.Squid], Src: rand.NewSource(42)})
+
+		// assert
+		require.Equal(t, p1.GetSpeed(), p2.GetSpeed())
+		require.Equal(t, p1.GetPosition(), p2.GetPosition())
+	})
+
+	t.Run("evades as its evader says", func(t *testing.T) {
+		// arrange
+		impl := prey.NewSpecies(prey.ConfigSpecies{
+			Profile:  species.Default[species.Seal],
+			Position: &positioner.Position{X: 10},
+			Evader:   prey.NewEvaderFlee(),
+		})
+		self := &simulator.Subject{Position: impl.GetPosition(), Speed: impl.GetSpeed()}
+		hunter := &simulator.Subject{Position: &positioner.Position{}, Speed: 12}
+
+		// act
+		heading := impl.Heading(self, hunter)
+
+		// assert
+		require.Equal(t, &positioner.Position{X: 1}, heading)
+	})
+}
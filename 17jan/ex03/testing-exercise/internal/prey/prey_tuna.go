@@ -2,19 +2,33 @@ package prey
 
 import (
 	"math/rand"
+	"sync"
 	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+	"testdoubles/internal/species"
 )
 
-// CreateTuna creates a new Tuna
-func CreateTuna() *Tuna {
+// CreateTuna creates a new Tuna (with random parameters)
+// - src is the source of the random numbers, so the same source replays the same tuna
+// - if src is nil, the global source of math/rand is used
+func CreateTuna(src rand.Source) *Tuna {
+	var rd *rand.Rand
+	random := rand.Float64
+	if src != nil {
+		rd = rand.New(src)
+		random = rd.Float64
+	}
+
 	// default config
-	// -> max speed: 252 m/s
-	speed := rand.Float64() * 252.0 + 15.0
-	// -> position: random
+	// -> speed: the one of the tuna of the catalogue
+	speed := species.Default[species.Tuna].Speed.Sample(rd)
+	// -> position: random in the default arena (at any depth, so the same seed keeps replaying the same tuna)
+	arena := positioner.NewArenaDefault()
+	min, max := arena.Min(), arena.Max()
 	position := &positioner.Position{
-		X: rand.Float64() * 500,
-		Y: rand.Float64() * 500,
-		Z: rand.Float64() * 500,
+		X: random()*(max.X-min.X) + min.X,
+		Y: random()*(max.Y-min.Y) + min.Y,
+		Z: random()*(max.Z-min.Z) + min.Z,
 	}
 
 	return &Tuna{
@@ -23,16 +37,46 @@ func CreateTuna() *Tuna {
 	}
 }
 
+// ConfigTuna is the configuration for Tuna
 type ConfigTuna struct {
 	Speed float64
 	Position *positioner.Position
+	// Evader is how the tuna reacts to a hunter (nil to let the simulator decide)
+	Evader Evader
+	// Acceleration of the tuna in m/s² (zero to reach its speed instantly)
+	Acceleration float64
+	// MaxSpeed is the top speed of the tuna in m/s (zero to use Speed as the top speed)
+	MaxSpeed float64
+	// CruiseSpeed is the speed in m/s the tuna can hold forever (zero to never get tired)
+	CruiseSpeed float64
+	// Stamina is how long in seconds the tuna can sprint above its cruise speed
+	Stamina float64
+	// Drag is how much the current carries the tuna, from 0 (not at all) to 1 (like the water)
+	Drag float64
 }
 
 // NewTuna creates a new Tuna
 func NewTuna(speed float64, position *positioner.Position) Prey {
 	return &Tuna{
 		speed: speed,
-		position: position,
+		position: position.Clone(),
+	}
+}
+
+// NewTunaWithConfig creates a new Tuna from its configuration
+func NewTunaWithConfig(config ConfigTuna) *Tuna {
+	return &Tuna{
+		speed: config.Speed,
+		position: config.Position.Clone(),
+		evader: config.Evader,
+		kinematics: simulator.Kinematics{
+			Acceleration: config.Acceleration,
+			MaxSpeed:     config.MaxSpeed,
+			CruiseSpeed:  config.CruiseSpeed,
+			Stamina:      config.Stamina,
+			MaxStamina:   config.Stamina,
+		},
+		drag: config.Drag,
 	}
 }
 
@@ -42,23 +86,77 @@ type Tuna struct {
 	speed float64
 	// position of the tuna
 	position *positioner.Position
+	// evader is how the tuna reacts to a hunter
+	evader Evader
+	// kinematics: acceleration, top speed and stamina of the tuna
+	kinematics simulator.Kinematics
+	// drag: how much the current carries the tuna
+	drag float64
+	// mu guards the speed and the position, so the tuna can be configured while it is hunted
+	mu sync.RWMutex
 }
 
 // GetSpeed returns the speed of the tuna
 func (t *Tuna) GetSpeed() (speed float64) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
 	// speed is the speed in m/s of the tuna
 	speed = t.speed
 	return
 }
 
-// GetPosition returns the position of the tuna
+// GetPosition returns a copy of the position of the tuna
 func (t *Tuna) GetPosition() (position *positioner.Position) {
-	position = t.position
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	position = t.position.Clone()
+	return
+}
+
+// GetKinematics returns the acceleration, top speed and stamina of the tuna
+func (t *Tuna) GetKinematics() (kinematics simulator.Kinematics) {
+	kinematics = t.kinematics
+	return
+}
+
+// GetDrag returns how much the current carries the tuna
+func (t *Tuna) GetDrag() (drag float64) {
+	drag = t.drag
 	return
 }
 
 // Configure configures the tuna
+// - the tuna keeps a copy of the position
 func (t *Tuna) Configure(speed float64, position *positioner.Position) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	(*t).speed = speed
-	(*t).position = position
+	(*t).position = position.Clone()
+}
+
+// Snapshot returns a copy of the state of the tuna, read at once
+func (t *Tuna) Snapshot() (subject *simulator.Subject) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	subject = &simulator.Subject{
+		Position:   t.position.Clone(),
+		Speed:      t.speed,
+		Kinematics: t.kinematics,
+		Drag:       t.drag,
+	}
+	return
+}
+
+// Heading returns the direction the tuna heads to when it is hunted
+// - it is nil if the tuna has no evader, so the simulator decides
+func (t *Tuna) Heading(prey, hunter *simulator.Subject) (heading *positioner.Position) {
+	if t.evader == nil {
+		return
+	}
+	heading = t.evader.Heading(prey, hunter)
+	return
 }
\ No newline at end of file
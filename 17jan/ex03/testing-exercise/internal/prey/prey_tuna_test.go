@@ -1,6 +1,8 @@
 package prey_test
 
 import (
+	"math/rand"
+	"sync"
 	"testdoubles/internal/positioner"
 	"testdoubles/internal/prey"
 	"testing"
@@ -91,4 +93,68 @@ func TestTuna_Configure(t *testing.T) {
 		require.Equal(t, outputSpeed, impl.GetSpeed())
 		require.Equal(t, outputPosition, impl.GetPosition())
 	})
-}
\ No newline at end of file
+}
+
+func TestTuna_CreateTuna(t *testing.T) {
+	t.Run("same seed, same tuna", func(t *testing.T) {
+		// act
+		tuna1 := prey.CreateTuna(rand.NewSource(42))
+		tuna2 := prey.CreateTuna(rand.NewSource(42))
+
+		// assert
+		require.Equal(t, tuna1.GetSpeed(), tuna2.GetSpeed())
+		require.Equal(t, tuna1.GetPosition(), tuna2.GetPosition())
+	})
+
+	t.Run("random parameters are in range", func(t *testing.T) {
+		// act
+		tuna := prey.CreateTuna(rand.NewSource(7))
+
+		// assert
+		require.GreaterOrEqual(t, tuna.GetSpeed(), 15.0)
+		require.LessOrEqual(t, tuna.GetSpeed(), 267.0)
+		for _, c := range []float64{tuna.GetPosition().X, tuna.GetPosition().Y, tuna.GetPosition().Z} {
+			require.GreaterOrEqual(t, c, 0.0)
+			require.LessOrEqual(t, c, 500.0)
+		}
+	})
+}
+
+func TestTuna_Concurrent(t *testing.T) {
+	t.Run("the tuna keeps a copy of its position", func(t *testing.T) {
+		// arrange
+		position := &positioner.Position{X: 1}
+		tuna := prey.NewTuna(1, position)
+
+		// act
+		position.X = 100
+		tuna.GetPosition().Y = 100
+
+		// assert
+		require.Equal(t, &positioner.Position{X: 1}, tuna.GetPosition())
+	})
+
+	t.Run("snapshots are never torn by a concurrent configure", func(t *testing.T) {
+		// run with go test -race
+		// arrange
+		// - every configuration has the speed equal to the X of the position
+		tuna := prey.NewTunaWithConfig(prey.ConfigTuna{Speed: 0, Position: &positioner.Position{}})
+
+		// act
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				tuna.Configure(float64(i), &positioner.Position{X: float64(i)})
+			}
+		}()
+
+		// assert
+		for i := 0; i < 1000; i++ {
+			s := tuna.Snapshot()
+			require.Equal(t, s.Speed, s.Position.X)
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,142 @@
+package scenario
+
+import (
+	"math/rand"
+	"testdoubles/internal/hunter"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/prey"
+	"testdoubles/internal/simulator"
+	"testdoubles/internal/species"
+)
+
+// defaultMaxTime is the default max time to catch the prey of a scenario (in seconds)
+const defaultMaxTime = 100.0
+
+// strategies are the pursuit strategies a hunter of a scenario can have, by name
+var strategies = map[string]func() hunter.PursuitStrategy{
+	"pure":             func() hunter.PursuitStrategy { return hunter.NewPursuitPure() },
+	"lead":             func() hunter.PursuitStrategy { return hunter.NewPursuitLead(0) },
+	"constant-bearing": func() hunter.PursuitStrategy { return hunter.NewPursuitConstantBearing() },
+}
+
+// evaders are the evaders a prey of a scenario can have, by name
+var evaders = map[string]func() prey.Evader{
+	"flee":        func() prey.Evader { return prey.NewEvaderFlee() },
+	"freeze":      func() prey.Evader { return prey.NewEvaderFreeze() },
+	"zigzag":      func() prey.Evader { return prey.NewEvaderZigZag(prey.ConfigEvaderZigZag{}) },
+	"spiral-dive": func() prey.Evader { return prey.NewEvaderSpiralDive(prey.ConfigEvaderSpiralDive{}) },
+}
+
+// Catalogue returns the default catalogue with the species of the scenario
+func (s *Scenario) Catalogue() (c species.Catalogue) {
+	c = make(species.Catalogue, len(species.Default)+len(s.Species))
+	for name, p := range species.Default {
+		c[name] = p
+	}
+	for name, p := range s.Species {
+		p.Name = name
+		c[name] = p
+	}
+	return
+}
+
+// NewPositioner returns the positioner of the scenario
+func (s *Scenario) NewPositioner() (ps positioner.Positioner) {
+	// - the metric was validated on load
+	ps, _ = positioner.NewPositioner(s.positionerConfig())
+	return
+}
+
+// positionerConfig returns the configuration of the positioner of the scenario
+func (s *Scenario) positionerConfig() (cfg positioner.ConfigPositioner) {
+	cfg = positioner.ConfigPositioner{Metric: s.Positioner.Metric, VerticalCost: s.Positioner.VerticalCost}
+	return
+}
+
+// NewArena returns the arena of the scenario
+// - without arena it is the default arena, or the whole Earth for geographic positions
+func (s *Scenario) NewArena() (a *positioner.Arena) {
+	switch {
+	case s.Arena != nil:
+		mode, _ := s.Arena.mode()
+		a = positioner.NewArena(positioner.ConfigArena{X: s.Arena.X, Y: s.Arena.Y, Z: s.Arena.Z, Mode: mode})
+	case s.Positioner.Metric == positioner.MetricHaversine:
+		a = positioner.NewArenaGeo()
+	default:
+		a = positioner.NewArenaDefault()
+	}
+	return
+}
+
+// NewSimulator returns the simulator of the scenario
+// - record makes the simulator record the trajectory of the subjects
+func (s *Scenario) NewSimulator(ps positioner.Positioner, arena *positioner.Arena, record bool) (sm simulator.CatchSimulator) {
+	maxTime := defaultMaxTime
+	if s.Simulator.MaxTime > 0 {
+		maxTime = s.Simulator.MaxTime
+	}
+
+	if s.Simulator.Kind == SimulatorStepped {
+		sm = simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: maxTime,
+			TimeStep:       s.Simulator.TimeStep,
+			CaptureRadius:  s.Simulator.CaptureRadius,
+			Positioner:     ps,
+			Record:         record,
+			Arena:          arena,
+			Murkiness:      s.Simulator.Murkiness,
+			Seed:           s.Seed,
+		})
+		return
+	}
+	sm = simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+		MaxTimeToCatch: maxTime,
+		Positioner:     ps,
+		TimeStep:       s.Simulator.TimeStep,
+		Record:         record,
+		Arena:          arena,
+	})
+	return
+}
+
+// NewSubjects returns the hunters and the preys of the scenario, in the order of the file
+// - the subjects without speed or position draw them from the seed of the scenario, hunters first
+func (s *Scenario) NewSubjects(sm simulator.CatchSimulator) (hunters []hunter.Hunter, preys []prey.Prey) {
+	src, catalogue, arena := rand.NewSource(s.Seed), s.Catalogue(), s.NewArena()
+	for _, sub := range s.Hunters {
+		cfg := hunter.ConfigSpecies{
+			Profile:   catalogue[sub.Species],
+			Position:  sub.Position,
+			Arena:     arena,
+			Src:       src,
+			Simulator: sm,
+			Drag:      sub.Drag,
+		}
+		if sub.Strategy != "" {
+			cfg.Strategy = strategies[sub.Strategy]()
+		}
+		h := hunter.NewSpecies(cfg)
+		if sub.Speed != nil {
+			h.Configure(*sub.Speed, h.GetPosition())
+		}
+		hunters = append(hunters, h)
+	}
+	for _, sub := range s.Preys {
+		cfg := prey.ConfigSpecies{
+			Profile:  catalogue[sub.Species],
+			Position: sub.Position,
+			Arena:    arena,
+			Src:      src,
+			Drag:     sub.Drag,
+		}
+		if sub.Evader != "" {
+			cfg.Evader = evaders[sub.Evader]()
+		}
+		p := prey.NewSpecies(cfg)
+		if sub.Speed != nil {
+			p.Configure(*sub.Speed, p.GetPosition())
+		}
+		preys = append(preys, p)
+	}
+	return
+}
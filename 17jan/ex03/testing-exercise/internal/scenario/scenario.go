@@ -0,0 +1,287 @@
+package scenario
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/species"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrScenarioInvalid is returned when a scenario is not valid or its file can not be parsed
+var ErrScenarioInvalid = errors.New("invalid scenario")
+
+// Kinds of simulators of a scenario
+const (
+	// SimulatorDefault is the CatchSimulatorDefault: both subjects move along the line that joins them
+	SimulatorDefault = "default"
+	// SimulatorStepped is the CatchSimulatorStepped: the subjects steer on each step
+	SimulatorStepped = "stepped"
+)
+
+// Scenario is what a simulation starts from: the species, the arena, the subjects and the simulator
+// It is read from a YAML or JSON file, so the parameters can change without building the application again
+type Scenario struct {
+	// Name of the scenario
+	Name string `yaml:"name"`
+	// Seed of the random numbers of the subjects, so the same file gives the same subjects
+	Seed int64 `yaml:"seed"`
+	// Positioner is the metric of the distances
+	Positioner PositionerSpec `yaml:"positioner"`
+	// Arena is where the subjects live (nil for the default arena, or the whole Earth for geographic positions)
+	Arena *ArenaSpec `yaml:"arena"`
+	// Simulator is how the hunts are simulated
+	Simulator SimulatorSpec `yaml:"simulator"`
+	// Species are more profiles of species, added to the default catalogue (or replacing the ones with the same name)
+	Species map[string]species.Profile `yaml:"species"`
+	// Hunters of the scenario
+	Hunters []SubjectSpec `yaml:"hunters"`
+	// Preys of the scenario
+	Preys []SubjectSpec `yaml:"preys"`
+
+	// root is the parsed file, to find the lines of the errors
+	root *yaml.Node
+}
+
+// PositionerSpec is the metric of the distances of a scenario
+type PositionerSpec struct {
+	// Metric is the name of the metric (default: euclidean)
+	Metric string `yaml:"metric"`
+	// VerticalCost multiplies the distance along Z of the weighted metric (default: 1)
+	VerticalCost float64 `yaml:"vertical_cost"`
+}
+
+// ArenaSpec is the arena of a scenario
+type ArenaSpec struct {
+	// X, Y and Z are the bounds of each axis (Z goes from the seabed to the surface)
+	X positioner.Bounds `yaml:"x"`
+	Y positioner.Bounds `yaml:"y"`
+	Z positioner.Bounds `yaml:"z"`
+	// Mode is what happens to a subject that crosses a boundary: clamp (default), reflect or wrap
+	Mode string `yaml:"mode"`
+}
+
+// SimulatorSpec is the simulator of a scenario
+type SimulatorSpec struct {
+	// Kind of the simulator: default or stepped (default: default)
+	Kind string `yaml:"kind"`
+	// MaxTime is the max time to catch the prey in seconds (default: 100)
+	MaxTime float64 `yaml:"max_time"`
+	// TimeStep is the time step of the simulation in seconds (default: the one of the simulator)
+	TimeStep float64 `yaml:"time_step"`
+	// CaptureRadius is the distance at which the hunter catches the prey in meters (only for the stepped simulator)
+	CaptureRadius float64 `yaml:"capture_radius"`
+	// Murkiness is how murky the water is for the perception of the hunter (only for the stepped simulator)
+	Murkiness float64 `yaml:"murkiness"`
+}
+
+// SubjectSpec is a hunter or a prey of a scenario
+type SubjectSpec struct {
+	// ID identifies the subject in the scenario
+	ID string `yaml:"id"`
+	// Species is the name of the profile of the subject, in the default catalogue or in the species of the scenario
+	Species string `yaml:"species"`
+	// Position of the subject (nil to spawn it at random at the depth of the species)
+	Position *positioner.Position `yaml:"position"`
+	// Speed of the subject in m/s (nil to draw it from the profile)
+	Speed *float64 `yaml:"speed"`
+	// Strategy is how a hunter chases the prey: pure, lead or constant-bearing (empty to let the simulator decide)
+	Strategy string `yaml:"strategy"`
+	// Evader is how a prey reacts to a hunter: flee, freeze, zigzag or spiral-dive (empty to let the simulator decide)
+	Evader string `yaml:"evader"`
+	// Drag is how much the current carries the subject, from 0 (not at all) to 1 (like the water)
+	Drag float64 `yaml:"drag"`
+}
+
+// LoadFile reads a scenario from a YAML or JSON file
+func LoadFile(path string) (s *Scenario, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	s, err = Load(f)
+	return
+}
+
+// Load reads a scenario in YAML or JSON (JSON is read as YAML, so the lines of the errors are the same)
+// - the fields are checked against the schema of a scenario first, then their values
+// - every error is an ErrScenarioInvalid with the line of the file where it is
+func Load(r io.Reader) (s *Scenario, err error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return
+	}
+
+	// parse
+	var document yaml.Node
+	if err = yaml.Unmarshal(data, &document); err != nil {
+		err = fmt.Errorf("%w: %v", ErrScenarioInvalid, err)
+		return
+	}
+	if len(bytes.TrimSpace(data)) == 0 || len(document.Content) == 0 {
+		err = fmt.Errorf("%w: the file is empty", ErrScenarioInvalid)
+		return
+	}
+	root := document.Content[0]
+
+	// schema
+	if err = schemaScenario.check(root, ""); err != nil {
+		return
+	}
+
+	// values
+	sc := &Scenario{root: root}
+	if err = root.Decode(sc); err != nil {
+		err = fmt.Errorf("%w: %v", ErrScenarioInvalid, err)
+		return
+	}
+	if err = sc.validate(); err != nil {
+		return
+	}
+	s = sc
+	return
+}
+
+// validate returns an error with the line of the first value of the scenario that is not valid
+func (s *Scenario) validate() (err error) {
+	// positioner
+	if _, err = positioner.NewPositioner(s.positionerConfig()); err != nil {
+		err = s.invalid(err.Error(), "positioner", "metric")
+		return
+	}
+	if s.Positioner.VerticalCost < 0 {
+		err = s.invalid("can not be negative", "positioner", "vertical_cost")
+		return
+	}
+
+	// arena
+	if s.Arena != nil {
+		for _, axis := range []struct {
+			name   string
+			bounds positioner.Bounds
+		}{{"x", s.Arena.X}, {"y", s.Arena.Y}, {"z", s.Arena.Z}} {
+			if axis.bounds.Min >= axis.bounds.Max {
+				err = s.invalid("min must be lower than max", "arena", axis.name)
+				return
+			}
+		}
+		if _, err = s.Arena.mode(); err != nil {
+			err = s.invalid(err.Error(), "arena", "mode")
+			return
+		}
+	}
+
+	// simulator
+	switch s.Simulator.Kind {
+	case "", SimulatorDefault, SimulatorStepped:
+	default:
+		err = s.invalid(fmt.Sprintf("unknown simulator %q", s.Simulator.Kind), "simulator", "kind")
+		return
+	}
+	for _, field := range []struct {
+		name  string
+		value float64
+	}{
+		{"max_time", s.Simulator.MaxTime},
+		{"time_step", s.Simulator.TimeStep},
+		{"capture_radius", s.Simulator.CaptureRadius},
+		{"murkiness", s.Simulator.Murkiness},
+	} {
+		if field.value < 0 {
+			err = s.invalid("can not be negative", "simulator", field.name)
+			return
+		}
+	}
+
+	// species
+	catalogue := s.Catalogue()
+	for _, name := range species.Catalogue(s.Species).Names() {
+		if err = catalogue[name].Validate(); err != nil {
+			err = s.invalid(err.Error(), "species", name)
+			return
+		}
+	}
+
+	// subjects
+	arena := s.NewArena()
+	ids := make(map[string]bool)
+	for _, group := range []struct {
+		name     string
+		subjects []SubjectSpec
+	}{{"hunters", s.Hunters}, {"preys", s.Preys}} {
+		for i, sub := range group.subjects {
+			if err = s.validateSubject(sub, group.name, i, catalogue, arena, ids); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// validateSubject returns an error with the line of the first value of a subject that is not valid
+func (s *Scenario) validateSubject(sub SubjectSpec, group string, i int, catalogue species.Catalogue, arena *positioner.Arena, ids map[string]bool) (err error) {
+	switch {
+	case sub.ID == "":
+		err = s.invalid("id is required", group, i)
+	case ids[sub.ID]:
+		err = s.invalid(fmt.Sprintf("%q is repeated", sub.ID), group, i, "id")
+	case sub.Species == "":
+		err = s.invalid("species is required", group, i)
+	}
+	if err != nil {
+		return
+	}
+	ids[sub.ID] = true
+
+	if _, err = catalogue.Lookup(sub.Species); err != nil {
+		err = s.invalid(err.Error(), group, i, "species")
+		return
+	}
+	if sub.Position != nil {
+		if err = arena.Validate(sub.Position); err != nil {
+			err = s.invalid(err.Error(), group, i, "position")
+			return
+		}
+	}
+	if sub.Speed != nil && *sub.Speed < 0 {
+		err = s.invalid("can not be negative", group, i, "speed")
+		return
+	}
+	if sub.Drag < 0 || sub.Drag > 1 {
+		err = s.invalid("must be between 0 and 1", group, i, "drag")
+		return
+	}
+	switch {
+	case group == "preys" && sub.Strategy != "":
+		err = s.invalid("a prey has no strategy", group, i, "strategy")
+	case group == "hunters" && sub.Evader != "":
+		err = s.invalid("a hunter has no evader", group, i, "evader")
+	case strategies[sub.Strategy] == nil && sub.Strategy != "":
+		err = s.invalid(fmt.Sprintf("unknown strategy %q", sub.Strategy), group, i, "strategy")
+	case evaders[sub.Evader] == nil && sub.Evader != "":
+		err = s.invalid(fmt.Sprintf("unknown evader %q", sub.Evader), group, i, "evader")
+	}
+	return
+}
+
+// invalid returns the error of the value at a path of the scenario, with its line
+func (s *Scenario) invalid(message string, path ...interface{}) (err error) {
+	node, name := at(s.root, path...)
+	err = invalid(node, name, message)
+	return
+}
+
+// mode returns the boundary mode of the arena (clamp if it has none)
+func (a *ArenaSpec) mode() (mode positioner.BoundaryMode, err error) {
+	if a.Mode == "" {
+		return
+	}
+	err = mode.UnmarshalText([]byte(a.Mode))
+	return
+}
@@ -0,0 +1,200 @@
+package scenario_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testdoubles/internal/hunter"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/prey"
+	"testdoubles/internal/scenario"
+	"testdoubles/internal/simulator"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// reef is a scenario with every section
+const reef = `name: reef
+seed: 7
+positioner:
+  metric: weighted
+  vertical_cost: 2
+arena:
+  x: {min: -100, max: 100}
+  y: {min: -100, max: 100}
+  z: {min: 0, max: 200}
+  mode: reflect
+simulator:
+  kind: stepped
+  max_time: 60
+  time_step: 0.5
+  capture_radius: 1
+species:
+  grouper:
+    speed: {min: 1, max: 4}
+    size: 1.2
+    depth: {min: 5, max: 100}
+hunters:
+  - id: h1
+    species: orca
+    position: {x: 0, y: 0, z: 50}
+    speed: 12
+    strategy: lead
+preys:
+  - id: p1
+    species: grouper
+    evader: flee
+    drag: 0.5
+  - id: p2
+    species: sardine
+`
+
+// Tests for Load
+func TestLoad(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		// act
+		s, err := scenario.Load(strings.NewReader(reef))
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "reef", s.Name)
+		require.Equal(t, int64(7), s.Seed)
+		require.Equal(t, scenario.PositionerSpec{Metric: positioner.MetricWeighted, VerticalCost: 2}, s.Positioner)
+		require.Equal(t, 200.0, s.Arena.Z.Max)
+		require.Equal(t, scenario.SimulatorStepped, s.Simulator.Kind)
+		require.Equal(t, 1.2, s.Species["grouper"].Size)
+		require.Len(t, s.Hunters, 1)
+		require.Equal(t, &positioner.Position{X: 0, Y: 0, Z: 50}, s.Hunters[0].Position)
+		require.Equal(t, 12.0, *s.Hunters[0].Speed)
+		require.Len(t, s.Preys, 2)
+		require.Nil(t, s.Preys[0].Position)
+		require.Equal(t, 0.5, s.Preys[0].Drag)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		// arrange
+		r := strings.NewReader(`{
+			"name": "open sea",
+			"hunters": [{"id": "h1", "species": "white-shark", "position": {"x": 1, "y": 2, "z": 3}}],
+			"preys": [{"id": "p1", "species": "tuna", "speed": 20}]
+		}`)
+
+		// act
+		s, err := scenario.Load(r)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "open sea", s.Name)
+		require.Nil(t, s.Arena)
+		require.Equal(t, "white-shark", s.Hunters[0].Species)
+		require.Equal(t, 20.0, *s.Preys[0].Speed)
+	})
+
+	t.Run("invalid scenarios - the error has the line of the value", func(t *testing.T) {
+		type testCase struct {
+			name     string
+			input    string
+			expected string
+		}
+		cases := []testCase{
+			// case 1: empty file
+			{name: "empty", input: "  \n", expected: "invalid scenario: the file is empty"},
+			// case 2: unknown field
+			{name: "unknown field", input: "name: a\nsimulator:\n  kind: default\n  speed: 3\n", expected: "invalid scenario: line 4: simulator.speed: is not a known field"},
+			// case 3: not a number
+			{name: "not a number", input: "hunters:\n  - id: h1\n    species: orca\n    speed: fast\n", expected: "invalid scenario: line 4: hunters[0].speed: must be a number"},
+			// case 4: repeated field
+			{name: "repeated field", input: "name: a\nname: b\n", expected: "invalid scenario: line 2: name: is repeated"},
+			// case 5: not a list
+			{name: "not a list", input: "preys:\n  id: p1\n", expected: "invalid scenario: line 2: preys: must be a list"},
+			// case 6: unknown species
+			{name: "unknown species", input: "hunters:\n  - id: h1\n    species: kraken\n", expected: "invalid scenario: line 3: hunters[0].species: unknown species: kraken"},
+			// case 7: repeated id
+			{name: "repeated id", input: "hunters:\n  - id: a\n    species: orca\npreys:\n  - id: a\n    species: tuna\n", expected: "invalid scenario: line 5: preys[0].id: \"a\" is repeated"},
+			// case 8: position out of the arena
+			{name: "position out of the arena", input: "arena:\n  x: {min: 0, max: 10}\n  y: {min: 0, max: 10}\n  z: {min: 0, max: 10}\nhunters:\n  - id: h1\n    species: orca\n    position: {x: 20, y: 0, z: 0}\n"},
+			// case 9: unknown simulator
+			{name: "unknown simulator", input: "simulator:\n  kind: quantum\n", expected: "invalid scenario: line 2: simulator.kind: unknown simulator \"quantum\""},
+			// case 10: strategy of a prey
+			{name: "strategy of a prey", input: "preys:\n  - id: p1\n    species: tuna\n    strategy: lead\n", expected: "invalid scenario: line 4: preys[0].strategy: a prey has no strategy"},
+			// case 11: missing species
+			{name: "missing species", input: "preys:\n  - id: p1\n", expected: "invalid scenario: line 2: preys[0]: species is required"},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				// act
+				s, err := scenario.Load(strings.NewReader(c.input))
+
+				// assert
+				require.ErrorIs(t, err, scenario.ErrScenarioInvalid)
+				require.Nil(t, s)
+				if c.expected != "" {
+					require.EqualError(t, err, c.expected)
+				} else {
+					require.Contains(t, err.Error(), "line 8: hunters[0].position: ")
+				}
+			})
+		}
+	})
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Run("existing file", func(t *testing.T) {
+		// arrange
+		path := filepath.Join(t.TempDir(), "reef.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(reef), 0o644))
+
+		// act
+		s, err := scenario.LoadFile(path)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, "reef", s.Name)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		// act
+		s, err := scenario.LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+
+		// assert
+		require.ErrorIs(t, err, os.ErrNotExist)
+		require.Nil(t, s)
+	})
+}
+
+func TestScenario_NewSubjects(t *testing.T) {
+	// arrange
+	s, err := scenario.Load(strings.NewReader(reef))
+	require.NoError(t, err)
+	sm := s.NewSimulator(s.NewPositioner(), s.NewArena(), false)
+
+	t.Run("subjects of the file", func(t *testing.T) {
+		// act
+		hunters, preys := s.NewSubjects(sm)
+
+		// assert
+		require.Len(t, hunters, 1)
+		require.Len(t, preys, 2)
+		require.Equal(t, 12.0, hunters[0].GetSpeed())
+		require.Equal(t, &positioner.Position{X: 0, Y: 0, Z: 50}, hunters[0].GetPosition())
+		require.Equal(t, "grouper", preys[0].(*prey.Species).GetProfile().Name)
+		require.Equal(t, 0.5, preys[0].(*prey.Species).GetDrag())
+		require.NoError(t, s.NewArena().Validate(preys[1].GetPosition()))
+		require.IsType(t, &hunter.Species{}, hunters[0])
+		require.IsType(t, &simulator.CatchSimulatorStepped{}, sm)
+	})
+
+	t.Run("same seed - same subjects", func(t *testing.T) {
+		// act
+		_, preysA := s.NewSubjects(sm)
+		_, preysB := s.NewSubjects(sm)
+
+		// assert
+		for i := range preysA {
+			require.Equal(t, preysA[i].GetSpeed(), preysB[i].GetSpeed())
+			require.Equal(t, preysA[i].GetPosition(), preysB[i].GetPosition())
+		}
+	})
+}
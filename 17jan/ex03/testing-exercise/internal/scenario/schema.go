@@ -0,0 +1,208 @@
+package scenario
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// schema is the shape a node of a scenario must have
+// - a scalar of a type, a mapping of known fields (or of any key), or a sequence of items
+type schema struct {
+	kind yaml.Kind
+	// tag of a scalar: !!float (also accepts integers), !!int or empty for any value
+	tag string
+	// fields of a mapping by key (nil for a mapping of any key)
+	fields map[string]*schema
+	// items of a sequence, or values of a mapping of any key
+	items *schema
+}
+
+// scalar returns the schema of a value of a type
+func scalar(tag string) *schema {
+	return &schema{kind: yaml.ScalarNode, tag: tag}
+}
+
+// mapping returns the schema of a mapping of known fields
+func mapping(fields map[string]*schema) *schema {
+	return &schema{kind: yaml.MappingNode, fields: fields}
+}
+
+// dictionary returns the schema of a mapping of any key
+func dictionary(values *schema) *schema {
+	return &schema{kind: yaml.MappingNode, items: values}
+}
+
+// sequence returns the schema of a sequence of items
+func sequence(items *schema) *schema {
+	return &schema{kind: yaml.SequenceNode, items: items}
+}
+
+var (
+	// schemaNumber is a number, integer or not
+	schemaNumber = scalar("!!float")
+	// schemaText is any value, read as text
+	schemaText = scalar("")
+	// schemaRange is the min and max of a value
+	schemaRange = mapping(map[string]*schema{"min": schemaNumber, "max": schemaNumber})
+	// schemaPosition is a position in the arena
+	schemaPosition = mapping(map[string]*schema{"x": schemaNumber, "y": schemaNumber, "z": schemaNumber})
+	// schemaSubject is a hunter or a prey
+	schemaSubject = mapping(map[string]*schema{
+		"id":       schemaText,
+		"species":  schemaText,
+		"position": schemaPosition,
+		"speed":    schemaNumber,
+		"strategy": schemaText,
+		"evader":   schemaText,
+		"drag":     schemaNumber,
+	})
+	// schemaScenario is the whole scenario
+	schemaScenario = mapping(map[string]*schema{
+		"name": schemaText,
+		"seed": scalar("!!int"),
+		"positioner": mapping(map[string]*schema{
+			"metric":        schemaText,
+			"vertical_cost": schemaNumber,
+		}),
+		"arena": mapping(map[string]*schema{
+			"x":    schemaRange,
+			"y":    schemaRange,
+			"z":    schemaRange,
+			"mode": schemaText,
+		}),
+		"simulator": mapping(map[string]*schema{
+			"kind":           schemaText,
+			"max_time":       schemaNumber,
+			"time_step":      schemaNumber,
+			"capture_radius": schemaNumber,
+			"murkiness":      schemaNumber,
+		}),
+		"species": dictionary(mapping(map[string]*schema{
+			"speed": mapping(map[string]*schema{
+				"min":     schemaNumber,
+				"max":     schemaNumber,
+				"mean":    schemaNumber,
+				"std_dev": schemaNumber,
+			}),
+			"acceleration":   schemaNumber,
+			"cruise":         schemaNumber,
+			"stamina":        schemaNumber,
+			"size":           schemaNumber,
+			"capture_radius": schemaNumber,
+			"depth":          schemaRange,
+		})),
+		"hunters": sequence(schemaSubject),
+		"preys":   sequence(schemaSubject),
+	})
+)
+
+// check returns an error with the line of the first node that does not have the shape of the schema
+// - path is where the node is in the scenario, e.g. hunters[0].position
+// - empty values (null) are always valid: they are left out
+func (sc *schema) check(node *yaml.Node, path string) (err error) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	switch sc.kind {
+	case yaml.ScalarNode:
+		switch {
+		case node.Kind != yaml.ScalarNode:
+			err = invalid(node, path, "must be a value")
+		case sc.tag == "!!float" && node.Tag != "!!float" && node.Tag != "!!int":
+			err = invalid(node, path, "must be a number")
+		case sc.tag == "!!int" && node.Tag != "!!int":
+			err = invalid(node, path, "must be an integer")
+		}
+	case yaml.MappingNode:
+		if node.Kind != yaml.MappingNode {
+			err = invalid(node, path, "must be a mapping")
+			return
+		}
+		seen := make(map[string]bool)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := join(path, key.Value)
+			if seen[key.Value] {
+				err = invalid(key, keyPath, "is repeated")
+				return
+			}
+			seen[key.Value] = true
+
+			child := sc.items
+			if sc.fields != nil {
+				child = sc.fields[key.Value]
+			}
+			if child == nil {
+				err = invalid(key, keyPath, "is not a known field")
+				return
+			}
+			if err = child.check(value, keyPath); err != nil {
+				return
+			}
+		}
+	case yaml.SequenceNode:
+		if node.Kind != yaml.SequenceNode {
+			err = invalid(node, path, "must be a list")
+			return
+		}
+		for i, item := range node.Content {
+			if err = sc.items.check(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// invalid returns the error of a node of the scenario
+func invalid(node *yaml.Node, path, message string) (err error) {
+	if path == "" {
+		path = "scenario"
+	}
+	err = fmt.Errorf("%w: line %d: %s: %s", ErrScenarioInvalid, node.Line, path, message)
+	return
+}
+
+// join returns the path of a field of a mapping
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// at returns the node at a path of keys and indices, e.g. at(root, "hunters", 0, "species"), and the name of the path
+// - if the path does not exist, it returns the deepest node found
+func at(node *yaml.Node, path ...interface{}) (found *yaml.Node, name string) {
+	found = node
+	lost := false
+	for _, step := range path {
+		var next *yaml.Node
+		switch s := step.(type) {
+		case string:
+			name = join(name, s)
+			for i := 0; found.Kind == yaml.MappingNode && i+1 < len(found.Content); i += 2 {
+				if found.Content[i].Value == s {
+					next = found.Content[i+1]
+					break
+				}
+			}
+		case int:
+			name = fmt.Sprintf("%s[%d]", name, s)
+			if found.Kind == yaml.SequenceNode && s < len(found.Content) {
+				next = found.Content[s]
+			}
+		}
+		if next != nil && !lost {
+			found = next
+			continue
+		}
+		lost = true
+	}
+	return
+}
@@ -1,13 +1,191 @@
 package simulator
 
-import "testdoubles/internal/positioner"
+import (
+	"errors"
+	"fmt"
+	"testdoubles/internal/positioner"
+)
 
+// Subject is a struct that represents a subject of the simulation (hunter or prey)
 type Subject struct {
 	// position of the subject
 	Position *positioner.Position
 	// speed of the subject (in m/s)
+	// - it is the current speed: with no top speed (MaxSpeed) it is also the top speed
 	Speed float64
-}	
+	// kinematics of the subject: how its speed changes along the simulation
+	Kinematics
+	// heading is the direction the subject is moving to (nil if unknown)
+	// - it does not need to be normalized
+	Heading *positioner.Position
+	// time elapsed since the start of the simulation (in seconds)
+	Time float64
+	// navigator decides where the subject heads to on each step of a stepped simulation (nil for the default behaviour)
+	Navigator Navigator
+	// perception is how the subject senses the other subject (nil to always know its exact position)
+	Perception *Perception
+	// velocity of the subject over the ground (in m/s): where it swims plus where the current carries it
+	// - it is set on each step of a stepped simulation
+	Velocity positioner.Velocity
+	// drag is how much the current carries the subject, from 0 (not at all) to 1 (like the water)
+	Drag float64
+	// capture radius is how close the hunter gets to the prey to catch it (in meters, zero for the one of the simulator)
+	// - only the stepped simulator looks at it: the closed form ones catch on contact
+	CaptureRadius float64
+}
+
+// Kinematics is a struct that represents how the speed of a subject changes along a simulation
+// - the zero value means a subject that holds its speed forever
+type Kinematics struct {
+	// acceleration of the subject (in m/s²), zero means it changes its speed instantly
+	Acceleration float64
+	// top speed of the subject (in m/s), zero means the speed of the subject is its top speed
+	MaxSpeed float64
+	// cruise speed of the subject (in m/s): it can be held forever, zero disables the stamina model
+	CruiseSpeed float64
+	// stamina left to the subject (in seconds above the cruise speed)
+	// - it drains while the subject moves above its cruise speed and recovers while below it
+	Stamina float64
+	// max stamina the subject recovers to (in seconds)
+	MaxStamina float64
+}
+
+// Navigator is an interface that represents how a subject steers during a simulation
+type Navigator interface {
+	// Heading returns the direction the subject heads to
+	// - self: is the current state of the subject that steers
+	// - other: is the current state of the opposing subject
+	// - heading: is the direction to head to (nil to keep the default behaviour)
+	Heading(self, other *Subject) (heading *positioner.Position)
+}
+
+// Snapshotter is an interface that represents a subject whose state can be read at once
+// - a concurrent Configure can not leave the snapshot with the speed of one configuration and the position of another
+type Snapshotter interface {
+	// Snapshot returns a copy of the state of the subject: position, speed, kinematics, perception and drag
+	Snapshot() (subject *Subject)
+}
+
+var (
+	// ErrPreyFaster is the reason of a failed hunt when the prey is faster than the hunter
+	ErrPreyFaster = errors.New("prey is faster than the hunter")
+	// ErrOutOfTime is the reason of a failed hunt when the hunter does not reach the prey in time
+	ErrOutOfTime = errors.New("hunter runs out of time")
+	// ErrOutOfRange is the reason of a failed hunt when the prey is too far to be reached in time even if it stood still
+	ErrOutOfRange = errors.New("prey is out of range")
+	// ErrNotDetected is the reason of a failed hunt when the hunter never senses the prey
+	ErrNotDetected = errors.New("prey was never detected")
+)
+
+// Outcome is the reason a simulation ended
+type Outcome int
+
+const (
+	// OutcomeCaught is when the hunter catches the prey
+	OutcomeCaught Outcome = iota
+	// OutcomePreyFaster is when the prey is faster than the hunter
+	OutcomePreyFaster
+	// OutcomeOutOfTime is when the hunter does not reach the prey in time
+	OutcomeOutOfTime
+	// OutcomeOutOfRange is when the prey is too far to be reached in time even if it stood still
+	OutcomeOutOfRange
+	// OutcomeNotDetected is when the hunter never senses the prey
+	OutcomeNotDetected
+)
+
+// outcomes are the names of the outcomes
+var outcomes = map[Outcome]string{
+	OutcomeCaught:      "caught",
+	OutcomePreyFaster:  "prey_faster",
+	OutcomeOutOfTime:   "out_of_time",
+	OutcomeOutOfRange:  "out_of_range",
+	OutcomeNotDetected: "not_detected",
+}
+
+// outcomeErrors are the reasons of the outcomes of failed hunts
+var outcomeErrors = map[Outcome]error{
+	OutcomePreyFaster:  ErrPreyFaster,
+	OutcomeOutOfTime:   ErrOutOfTime,
+	OutcomeOutOfRange:  ErrOutOfRange,
+	OutcomeNotDetected: ErrNotDetected,
+}
+
+// String returns the name of the outcome
+func (o Outcome) String() string {
+	name, ok := outcomes[o]
+	if !ok {
+		return "unknown"
+	}
+	return name
+}
+
+// MarshalText returns the name of the outcome (used to encode it in JSON)
+func (o Outcome) MarshalText() (text []byte, err error) {
+	text = []byte(o.String())
+	return
+}
+
+// UnmarshalText parses the outcome from its name
+func (o *Outcome) UnmarshalText(text []byte) (err error) {
+	for outcome, name := range outcomes {
+		if name == string(text) {
+			*o = outcome
+			return
+		}
+	}
+	err = fmt.Errorf("unknown outcome %q", text)
+	return
+}
+
+// Err returns the reason of the outcome as an error (nil if the hunter caught the prey)
+func (o Outcome) Err() (err error) {
+	err = outcomeErrors[o]
+	return
+}
+
+// CatchResult is a struct that represents the result of a simulation
+type CatchResult struct {
+	// Outcome is the reason the simulation ended
+	Outcome Outcome
+	// Duration is the duration of the catch (in seconds), zero if the hunter did not catch the prey
+	Duration float64
+	// HunterDistance is the distance travelled by the hunter (in meters)
+	HunterDistance float64
+	// PreyDistance is the distance travelled by the prey (in meters)
+	PreyDistance float64
+	// ClosestApproach is the shortest distance between the hunter and the prey (in meters)
+	ClosestApproach float64
+	// HunterPosition is the final position of the hunter
+	HunterPosition *positioner.Position
+	// PreyPosition is the final position of the prey
+	PreyPosition *positioner.Position
+	// Trajectory is the path of the subjects along the simulation (nil if the simulator does not record it)
+	Trajectory *Trajectory
+	// HunterStart and PreyStart are the states the hunt started from (set by the hunters, nil if unknown)
+	HunterStart *Subject
+	PreyStart   *Subject
+}
+
+// Caught returns true if the hunter caught the prey
+func (r *CatchResult) Caught() (ok bool) {
+	ok = r.Outcome == OutcomeCaught
+	return
+}
+
+// outcomeOf returns the reason a hunter did not catch a prey
+// - distance: is the distance between them at the start (in meters)
+// - maxTime: is the max time to catch the prey (in seconds)
+func outcomeOf(hunter, prey *Subject, distance, maxTime float64) (outcome Outcome) {
+	switch {
+	case prey.TopSpeed() >= hunter.TopSpeed():
+		outcome = OutcomePreyFaster
+	case distance >= hunter.TopSpeed()*maxTime:
+		outcome = OutcomeOutOfRange
+	default:
+		outcome = OutcomeOutOfTime
+	}
+	return
+}
 
 // CatchSimulator is an interface that represents a catch simulator
 // It is used to simulate if a hunter can catch a prey
@@ -15,6 +193,6 @@ type CatchSimulator interface {
 	// CanCatch returns true if the hunter can catch the prey
 	// - hunter: is the hunter subject
 	// - prey: is the prey subject
-	// - duration: is the duration of the catch (in seconds)
-	CanCatch(hunter, prey *Subject) (duration float64, ok bool)
+	// - result: is how the simulation went (duration of the catch in seconds, distances, final positions...), never nil
+	CanCatch(hunter, prey *Subject) (result *CatchResult, ok bool)
 }
\ No newline at end of file
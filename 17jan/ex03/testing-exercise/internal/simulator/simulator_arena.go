@@ -0,0 +1,15 @@
+package simulator
+
+import "testdoubles/internal/positioner"
+
+// constrain keeps the final positions of the subjects of the result inside the arena
+// - it is used by the simulators that solve the hunt in closed form, so the walls do not change the hunt itself
+// - a nil arena has no bounds
+func constrain(arena *positioner.Arena, result *CatchResult) {
+	if arena == nil {
+		return
+	}
+	hunterPosition, _ := arena.Constrain(*result.HunterPosition, positioner.Position{})
+	preyPosition, _ := arena.Constrain(*result.PreyPosition, positioner.Position{})
+	result.HunterPosition, result.PreyPosition = &hunterPosition, &preyPosition
+}
@@ -1,18 +1,39 @@
 package simulator
 
-import "testdoubles/internal/positioner"
+import (
+	"math"
+	"testdoubles/internal/positioner"
+)
 
 // ConfigCatchSimulatorDefault is the configuration for CatchSimulatorDefault
 type ConfigCatchSimulatorDefault struct {
 	MaxTimeToCatch float64
 	Positioner     positioner.Positioner
+	// TimeStep is used to integrate the speed of subjects with kinematics (in seconds)
+	TimeStep float64
+	// Record makes the simulator record the trajectory of the subjects (only the start and the end)
+	Record bool
+	// Recorder also records the trajectory of the subjects (nil for none), whether Record is set or not
+	Recorder TrajectoryRecorder
+	// Arena keeps the final positions of the subjects inside its bounds (nil to have no bounds)
+	Arena *positioner.Arena
 }
 
 // NewCatchSimulatorDefault creates a new CatchSimulatorDefault
 func NewCatchSimulatorDefault(cfg *ConfigCatchSimulatorDefault) (sm *CatchSimulatorDefault) {
+	// default config
+	timeStep := defaultTimeStep
+	if cfg.TimeStep > 0 {
+		timeStep = cfg.TimeStep
+	}
+
 	sm = &CatchSimulatorDefault{
 		maxTimeToCatch: cfg.MaxTimeToCatch,
 		ps:             cfg.Positioner,
+		timeStep:       timeStep,
+		record:         cfg.Record,
+		recorder:       cfg.Recorder,
+		arena:          cfg.Arena,
 	}
 	return
 }
@@ -23,22 +44,147 @@ type CatchSimulatorDefault struct {
 	maxTimeToCatch float64
 	// positioner: used to calculate the distance between the hunter and the prey
 	ps positioner.Positioner
+	// time step in seconds to integrate the speed of subjects with kinematics
+	timeStep float64
+	// record the trajectory of the subjects
+	record bool
+	// recorder also records the trajectory of the subjects
+	recorder TrajectoryRecorder
+	// arena where the subjects end
+	arena *positioner.Arena
 }
 
 // CanCatch returns true if the hunter can catch the prey
-func (c *CatchSimulatorDefault) CanCatch(hunter, prey *Subject) (duration float64, ok bool) {
+// Both subjects move along the line that joins them: the prey flees directly away from the hunter
+func (c *CatchSimulatorDefault) CanCatch(hunter, prey *Subject) (result *CatchResult, ok bool) {
 	// calculate distance between hunter and prey (in meters)
 	distance := c.ps.GetLinearDistance(hunter.Position, prey.Position)
 
-	// calculate time to catch the prey (in seconds)
-	timeToCatch := distance / (hunter.Speed - prey.Speed)
+	// calculate the time to catch the prey (in seconds) and the distance travelled by each subject (in meters)
+	// - the copies of the subjects end with their final speed
+	h, p := *hunter, *prey
+	var timeToCatch, hunterDistance, preyDistance, closest float64
+	if hunter.HasKinematics() || prey.HasKinematics() {
+		// subjects with kinematics change their speed along the way
+		timeToCatch, hunterDistance, preyDistance, closest = c.integrate(&h, &p, distance)
+	} else {
+		timeToCatch, hunterDistance, preyDistance, closest = c.solve(hunter, prey, distance)
+	}
 
 	// check if hunter can catch the prey
-	ok = timeToCatch >= 0 && timeToCatch <= c.maxTimeToCatch
-	if !ok {
-		return
+	ok = closest <= 0
+	result = &CatchResult{
+		HunterDistance:  hunterDistance,
+		PreyDistance:    preyDistance,
+		ClosestApproach: math.Max(closest, 0),
+	}
+	if ok {
+		result.Outcome = OutcomeCaught
+		result.Duration = timeToCatch
+	} else {
+		result.Outcome = outcomeOf(hunter, prey, distance, c.maxTimeToCatch)
+	}
+
+	// final positions: along the line from the hunter to the prey
+	// - the distances are of the metric of the positioner: scale them to the coordinates (1 for the euclidean one)
+	line := prey.Position.Sub(*hunter.Position)
+	u := line.Normalize()
+	scale := 1.0
+	if distance > 0 && !math.IsInf(distance, 1) {
+		scale = line.Length() / distance
+	}
+	hunterPosition := hunter.Position.Add(u.Scale(hunterDistance * scale))
+	preyPosition := prey.Position.Add(u.Scale(preyDistance * scale))
+	result.HunterPosition, result.PreyPosition = &hunterPosition, &preyPosition
+	constrain(c.arena, result)
+
+	// trajectory: the start and the end
+	if c.record || c.recorder != nil {
+		elapsed := c.maxTimeToCatch
+		if ok {
+			elapsed = timeToCatch
+		}
+		recordEnds(result, c.record, c.recorder,
+			&Subject{Position: hunter.Position, Speed: hunter.Speed, Heading: &u},
+			&Subject{Position: prey.Position, Speed: prey.Speed, Heading: &u},
+			&Subject{Position: result.HunterPosition, Speed: h.Speed, Heading: &u, Time: elapsed},
+			&Subject{Position: result.PreyPosition, Speed: p.Speed, Heading: &u, Time: elapsed},
+		)
+	}
+	return
+}
+
+// solve returns the time the hunter takes to close the distance to the prey with constant speeds,
+// the distance travelled by each subject and the closest they got (zero if caught)
+func (c *CatchSimulatorDefault) solve(hunter, prey *Subject, distance float64) (timeToCatch, hunterDistance, preyDistance, closest float64) {
+	// calculate time to catch the prey (in seconds)
+	closing := hunter.Speed - prey.Speed
+	switch {
+	case distance == 0:
+		timeToCatch = 0
+	case closing > 0:
+		timeToCatch = distance / closing
+	default:
+		timeToCatch = math.Inf(1)
 	}
 
-	duration = timeToCatch
+	// the hunter does not catch the prey in time: they move until the time is over
+	elapsed := timeToCatch
+	if timeToCatch > c.maxTimeToCatch {
+		elapsed = c.maxTimeToCatch
+	}
+
+	hunterDistance = hunter.Speed * elapsed
+	preyDistance = prey.Speed * elapsed
+	// the distance only shrinks if the hunter is faster
+	closest = math.Min(distance, distance-(hunterDistance-preyDistance))
+	if timeToCatch <= c.maxTimeToCatch {
+		closest = 0
+	}
 	return
+}
+
+// integrate returns the time the hunter takes to close the distance to the prey,
+// moving both along one line step by step as their speeds change,
+// the distance travelled by each subject and the closest they got (zero if caught)
+func (c *CatchSimulatorDefault) integrate(hunter, prey *Subject, distance float64) (timeToCatch, hunterDistance, preyDistance, closest float64) {
+	gap := distance
+	closest = distance
+	for step := 0; ; step++ {
+		elapsed := float64(step) * c.timeStep
+		closest = math.Min(closest, gap)
+		if gap <= 0 {
+			timeToCatch = elapsed
+			return
+		}
+		if elapsed >= c.maxTimeToCatch {
+			return
+		}
+		// - once neither subject changes its speed, a hunter that does not gain never will: the rest of the time at once
+		if hunter.Speed <= prey.Speed && hunter.Steady() && prey.Steady() {
+			remaining := c.maxTimeToCatch - elapsed
+			hunterDistance += hunter.Speed * remaining
+			preyDistance += prey.Speed * remaining
+			return
+		}
+		dt := math.Min(c.timeStep, c.maxTimeToCatch-elapsed)
+
+		// both subjects speed up or slow down
+		hunter.Throttle(dt)
+		prey.Throttle(dt)
+
+		// check if the hunter closes the distance in the middle of the step
+		closing := hunter.Speed - prey.Speed
+		if closing > 0 && closing*dt >= gap {
+			t := gap / closing
+			timeToCatch = elapsed + t
+			hunterDistance += hunter.Speed * t
+			preyDistance += prey.Speed * t
+			closest = 0
+			return
+		}
+		hunterDistance += hunter.Speed * dt
+		preyDistance += prey.Speed * dt
+		gap -= closing * dt
+	}
 }
\ No newline at end of file
@@ -24,12 +24,20 @@ func TestCatchSimulatorDefault_CanCatch(t *testing.T) {
 		// act
 		inputHunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
 		inputPrey := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 100, Y: 0, Z: 0}}
-		duration, ok := impl.CanCatch(inputHunter, inputPrey)
+		result, ok := impl.CanCatch(inputHunter, inputPrey)
 
 		// assert
-		expectedDuration := 20.0
+		expectedResult := &simulator.CatchResult{
+			Outcome:         simulator.OutcomeCaught,
+			Duration:        20.0,
+			HunterDistance:  200.0,
+			PreyDistance:    100.0,
+			ClosestApproach: 0.0,
+			HunterPosition:  &positioner.Position{X: 200, Y: 0, Z: 0},
+			PreyPosition:    &positioner.Position{X: 200, Y: 0, Z: 0},
+		}
 		expectedOk := true		
-		require.Equal(t, expectedDuration, duration)
+		require.Equal(t, expectedResult, result)
 		require.Equal(t, expectedOk, ok)
 	})
 	
@@ -47,16 +55,50 @@ func TestCatchSimulatorDefault_CanCatch(t *testing.T) {
 		// act
 		inputHunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
 		inputPrey := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 1000, Y: 0, Z: 0}}
-		duration, ok := impl.CanCatch(inputHunter, inputPrey)
+		result, ok := impl.CanCatch(inputHunter, inputPrey)
 
 		// assert
-		expectedDuration := 0.0
+		expectedResult := &simulator.CatchResult{
+			Outcome:         simulator.OutcomeOutOfRange,
+			Duration:        0.0,
+			HunterDistance:  1000.0,
+			PreyDistance:    500.0,
+			ClosestApproach: 500.0,
+			HunterPosition:  &positioner.Position{X: 1000, Y: 0, Z: 0},
+			PreyPosition:    &positioner.Position{X: 1500, Y: 0, Z: 0},
+		}
 		expectedOk := false
-		require.Equal(t, expectedDuration, duration)
+		require.Equal(t, expectedResult, result)
 		require.Equal(t, expectedOk, ok)
 
 	})
 
+	t.Run("Hunter can not catch the prey - out of time", func(t *testing.T) {
+		// arrange
+		ps := positioner.NewPositionerStub()
+		ps.GetLinearDistanceFunc = func(from, to *positioner.Position) (distance float64) {
+			distance = 600
+			return
+		}
+
+		cfgImpl := &simulator.ConfigCatchSimulatorDefault{MaxTimeToCatch: 100, Positioner: ps}
+		impl := simulator.NewCatchSimulatorDefault(cfgImpl)
+
+		// act
+		inputHunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
+		inputPrey := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 600, Y: 0, Z: 0}}
+		result, ok := impl.CanCatch(inputHunter, inputPrey)
+
+		// assert
+		expectedOutcome := simulator.OutcomeOutOfTime
+		expectedClosestApproach := 100.0
+		expectedOk := false
+		require.Equal(t, expectedOutcome, result.Outcome)
+		require.Equal(t, expectedClosestApproach, result.ClosestApproach)
+		require.Equal(t, expectedOk, ok)
+		require.ErrorIs(t, result.Outcome.Err(), simulator.ErrOutOfTime)
+	})
+
 	t.Run("Hunter can not catch the prey - hunter slower", func(t *testing.T) {
 		// arrange
 		ps := positioner.NewPositionerStub()
@@ -71,12 +113,43 @@ func TestCatchSimulatorDefault_CanCatch(t *testing.T) {
 		// act
 		inputHunter := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
 		inputPrey := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 100, Y: 0, Z: 0}}
-		duration, ok := impl.CanCatch(inputHunter, inputPrey)
+		result, ok := impl.CanCatch(inputHunter, inputPrey)
 
 		// assert
-		expectedDuration := 0.0
+		expectedResult := &simulator.CatchResult{
+			Outcome:         simulator.OutcomePreyFaster,
+			Duration:        0.0,
+			HunterDistance:  500.0,
+			PreyDistance:    1000.0,
+			ClosestApproach: 100.0,
+			HunterPosition:  &positioner.Position{X: 500, Y: 0, Z: 0},
+			PreyPosition:    &positioner.Position{X: 1100, Y: 0, Z: 0},
+		}
 		expectedOk := false
-		require.Equal(t, expectedDuration, duration)
+		require.Equal(t, expectedResult, result)
 		require.Equal(t, expectedOk, ok)
 	})
-}
\ No newline at end of file
+}
+
+func TestCatchSimulatorDefault_CanCatch_Metric(t *testing.T) {
+	// arrange
+	// - manhattan distance of 70 meters, euclidean of 50
+	cfgImpl := &simulator.ConfigCatchSimulatorDefault{MaxTimeToCatch: 100, Positioner: positioner.NewPositionerManhattan()}
+	impl := simulator.NewCatchSimulatorDefault(cfgImpl)
+
+	// act
+	inputHunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{}}
+	inputPrey := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 30, Y: 40}}
+	result, ok := impl.CanCatch(inputHunter, inputPrey)
+
+	// assert
+	// - the distances are of the metric, and both subjects end where they meet
+	require.True(t, ok)
+	require.Equal(t, 14.0, result.Duration)
+	require.Equal(t, 140.0, result.HunterDistance)
+	require.Equal(t, 70.0, result.PreyDistance)
+	require.InDelta(t, 60.0, result.HunterPosition.X, 1e-9)
+	require.InDelta(t, 80.0, result.HunterPosition.Y, 1e-9)
+	require.InDelta(t, result.HunterPosition.X, result.PreyPosition.X, 1e-9)
+	require.InDelta(t, result.HunterPosition.Y, result.PreyPosition.Y, 1e-9)
+}
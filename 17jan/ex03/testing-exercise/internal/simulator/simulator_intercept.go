@@ -0,0 +1,167 @@
+package simulator
+
+import (
+	"math"
+	"testdoubles/internal/positioner"
+)
+
+// ConfigCatchSimulatorIntercept is the configuration for CatchSimulatorIntercept
+type ConfigCatchSimulatorIntercept struct {
+	// MaxTimeToCatch is the max time to catch the prey (in seconds)
+	MaxTimeToCatch float64
+	// Record makes the simulator record the trajectory of the subjects (only the start and the end)
+	Record bool
+	// Recorder also records the trajectory of the subjects (nil for none), whether Record is set or not
+	Recorder TrajectoryRecorder
+	// Arena keeps the final positions of the subjects inside its bounds (nil to have no bounds)
+	Arena *positioner.Arena
+}
+
+// NewCatchSimulatorIntercept creates a new CatchSimulatorIntercept
+func NewCatchSimulatorIntercept(cfg *ConfigCatchSimulatorIntercept) (sm *CatchSimulatorIntercept) {
+	sm = &CatchSimulatorIntercept{
+		maxTimeToCatch: cfg.MaxTimeToCatch,
+		record:         cfg.Record,
+		recorder:       cfg.Recorder,
+		arena:          cfg.Arena,
+	}
+	return
+}
+
+// InterceptResult is the result of an intercept
+type InterceptResult struct {
+	// Time is the time the hunter takes to reach the prey (in seconds)
+	Time float64
+	// Position is where the hunter reaches the prey
+	Position *positioner.Position
+}
+
+// CatchSimulatorIntercept is an implementation of CatchSimulator that solves the intercept in closed form
+// - the prey moves with constant velocity: its speed along its heading (or directly away from the hunter if it has none)
+// - the hunter moves at its speed in a straight line to the earliest point where it can meet the prey
+// - acceleration and stamina are not taken into account
+type CatchSimulatorIntercept struct {
+	// max time to catch the prey in seconds
+	maxTimeToCatch float64
+	// record the trajectory of the subjects
+	record bool
+	// recorder also records the trajectory of the subjects
+	recorder TrajectoryRecorder
+	// arena where the subjects end
+	arena *positioner.Arena
+}
+
+// CanCatch returns true if the hunter can catch the prey
+// - if the hunter can not catch the prey, it is moved in a straight line toward the start position of the prey
+func (c *CatchSimulatorIntercept) CanCatch(hunter, prey *Subject) (result *CatchResult, ok bool) {
+	r := prey.Position.Sub(*hunter.Position)
+	v := c.velocity(hunter, prey)
+
+	intercept, ok := c.Intercept(hunter, prey)
+	if ok {
+		position := *intercept.Position
+		result = &CatchResult{
+			Outcome:        OutcomeCaught,
+			Duration:       intercept.Time,
+			HunterDistance: hunter.Speed * intercept.Time,
+			PreyDistance:   v.Length() * intercept.Time,
+			HunterPosition: intercept.Position,
+			PreyPosition:   &position,
+		}
+		constrain(c.arena, result)
+		c.recordEnds(result, hunter, prey, intercept.Position.Sub(*hunter.Position), v)
+		return
+	}
+
+	// the hunter chases the prey in a straight line until the time is over
+	vh := r.Normalize().Scale(hunter.Speed)
+	hunterPosition := hunter.Position.Add(vh.Scale(c.maxTimeToCatch))
+	preyPosition := prey.Position.Add(v.Scale(c.maxTimeToCatch))
+	result = &CatchResult{
+		Outcome:         outcomeOf(hunter, prey, r.Length(), c.maxTimeToCatch),
+		HunterDistance:  hunter.Speed * c.maxTimeToCatch,
+		PreyDistance:    v.Length() * c.maxTimeToCatch,
+		ClosestApproach: closestApproach(r, v.Sub(vh), c.maxTimeToCatch),
+		HunterPosition:  &hunterPosition,
+		PreyPosition:    &preyPosition,
+	}
+	constrain(c.arena, result)
+	c.recordEnds(result, hunter, prey, vh, v)
+	return
+}
+
+// recordEnds records the start and the end of the trajectory of the subjects, if the simulator records them
+func (c *CatchSimulatorIntercept) recordEnds(result *CatchResult, hunter, prey *Subject, hunterHeading, preyHeading positioner.Position) {
+	if !c.record && c.recorder == nil {
+		return
+	}
+	elapsed := c.maxTimeToCatch
+	if result.Caught() {
+		elapsed = result.Duration
+	}
+
+	recordEnds(result, c.record, c.recorder,
+		&Subject{Position: hunter.Position, Speed: hunter.Speed, Heading: &hunterHeading},
+		&Subject{Position: prey.Position, Speed: prey.Speed, Heading: &preyHeading},
+		&Subject{Position: result.HunterPosition, Speed: hunter.Speed, Heading: &hunterHeading, Time: elapsed},
+		&Subject{Position: result.PreyPosition, Speed: prey.Speed, Heading: &preyHeading, Time: elapsed},
+	)
+}
+
+// velocity returns the constant velocity of the prey
+func (c *CatchSimulatorIntercept) velocity(hunter, prey *Subject) (v positioner.Position) {
+	heading := prey.Position.Sub(*hunter.Position)
+	if prey.Heading != nil {
+		heading = *prey.Heading
+	}
+	v = heading.Normalize().Scale(prey.Speed)
+	return
+}
+
+// Intercept returns the earliest time and position where the hunter can meet the prey
+// It solves for the smallest t >= 0 such that |P_prey + V_prey*t - P_hunter| = s_hunter*t
+func (c *CatchSimulatorIntercept) Intercept(hunter, prey *Subject) (result InterceptResult, ok bool) {
+	// relative position of the prey
+	r := prey.Position.Sub(*hunter.Position)
+
+	// velocity of the prey
+	v := c.velocity(hunter, prey)
+
+	// (v·v - s²)t² + 2(r·v)t + r·r = 0
+	a := v.Dot(v) - hunter.Speed*hunter.Speed
+	b := 2 * r.Dot(v)
+	cc := r.Dot(r)
+
+	t := math.Inf(1)
+	switch {
+	case cc == 0:
+		// already together
+		t = 0
+	case math.Abs(a) < 1e-12:
+		// same speed: only one root
+		if b < 0 {
+			t = -cc / b
+		}
+	default:
+		disc := b*b - 4*a*cc
+		if disc < 0 {
+			return
+		}
+		sq := math.Sqrt(disc)
+		for _, root := range []float64{(-b - sq) / (2 * a), (-b + sq) / (2 * a)} {
+			if root >= 0 && root < t {
+				t = root
+			}
+		}
+	}
+
+	// check if hunter can catch the prey
+	ok = t <= c.maxTimeToCatch
+	if !ok {
+		return
+	}
+
+	position := prey.Position.Add(v.Scale(t))
+	result = InterceptResult{Time: t, Position: &position}
+	return
+}
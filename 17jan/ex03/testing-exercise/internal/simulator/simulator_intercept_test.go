@@ -0,0 +1,152 @@
+package simulator_test
+
+import (
+	"math"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Unit Tests for CatchSimulatorIntercept
+func TestCatchSimulatorIntercept_Intercept(t *testing.T) {
+	type input struct{ hunter, prey *simulator.Subject }
+	type output struct {
+		result simulator.InterceptResult
+		ok     bool
+	}
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	cases := []testCase{
+		// case 1: the prey does not move
+		{
+			name: "prey still",
+			input: input{
+				hunter: &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}},
+				prey:   &simulator.Subject{Speed: 0, Position: &positioner.Position{X: 0, Y: 0, Z: 100}},
+			},
+			output: output{result: simulator.InterceptResult{Time: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 100}}, ok: true},
+		},
+		// case 2: the prey flees directly away - same as the linear model
+		{
+			name: "prey flees directly away",
+			input: input{
+				hunter: &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}},
+				prey:   &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 100, Y: 0, Z: 0}},
+			},
+			output: output{result: simulator.InterceptResult{Time: 20, Position: &positioner.Position{X: 200, Y: 0, Z: 0}}, ok: true},
+		},
+		// case 3: the prey runs perpendicular - straight collision course
+		{
+			name: "prey runs perpendicular",
+			input: input{
+				hunter: &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}},
+				prey: &simulator.Subject{
+					Speed:    5,
+					Position: &positioner.Position{X: 100, Y: 0, Z: 0},
+					Heading:  &positioner.Position{X: 0, Y: 2, Z: 0},
+				},
+			},
+			output: output{
+				result: simulator.InterceptResult{
+					Time:     100 / math.Sqrt(75),
+					Position: &positioner.Position{X: 100, Y: 500 / math.Sqrt(75), Z: 0},
+				},
+				ok: true,
+			},
+		},
+		// case 4: the prey comes toward the hunter at the same speed
+		{
+			name: "prey comes toward the hunter - same speed",
+			input: input{
+				hunter: &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}},
+				prey: &simulator.Subject{
+					Speed:    10,
+					Position: &positioner.Position{X: 100, Y: 0, Z: 0},
+					Heading:  &positioner.Position{X: -1, Y: 0, Z: 0},
+				},
+			},
+			output: output{result: simulator.InterceptResult{Time: 5, Position: &positioner.Position{X: 50, Y: 0, Z: 0}}, ok: true},
+		},
+		// case 5: the prey is faster
+		{
+			name: "prey faster",
+			input: input{
+				hunter: &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 0, Y: 0, Z: 0}},
+				prey:   &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 100, Y: 0, Z: 0}},
+			},
+			output: output{result: simulator.InterceptResult{}, ok: false},
+		},
+		// case 6: the intercept happens after the max time
+		{
+			name: "out of time",
+			input: input{
+				hunter: &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}},
+				prey:   &simulator.Subject{Speed: 9.5, Position: &positioner.Position{X: 100, Y: 0, Z: 0}},
+			},
+			output: output{result: simulator.InterceptResult{}, ok: false},
+		},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// arrange
+			impl := simulator.NewCatchSimulatorIntercept(&simulator.ConfigCatchSimulatorIntercept{MaxTimeToCatch: 100})
+
+			// act
+			result, ok := impl.Intercept(c.input.hunter, c.input.prey)
+
+			// assert
+			require.Equal(t, c.output.ok, ok)
+			require.InDelta(t, c.output.result.Time, result.Time, 1e-9)
+			if c.output.result.Position == nil {
+				require.Nil(t, result.Position)
+				return
+			}
+			require.InDelta(t, c.output.result.Position.X, result.Position.X, 1e-9)
+			require.InDelta(t, c.output.result.Position.Y, result.Position.Y, 1e-9)
+			require.InDelta(t, c.output.result.Position.Z, result.Position.Z, 1e-9)
+		})
+	}
+}
+
+// Tests checking the other simulators against the exact intercept
+func TestCatchSimulatorIntercept_Baseline(t *testing.T) {
+	// arrange
+	intercept := simulator.NewCatchSimulatorIntercept(&simulator.ConfigCatchSimulatorIntercept{MaxTimeToCatch: 100})
+	linear := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+		MaxTimeToCatch: 100,
+		Positioner:     positioner.NewPositionerDefault(),
+	})
+	stepped := simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+		MaxTimeToCatch: 100,
+		TimeStep:       0.01,
+		CaptureRadius:  0.01,
+		Positioner:     positioner.NewPositionerDefault(),
+	})
+	hunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
+	prey := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 30, Y: 40, Z: 0}}
+
+	// act
+	exactResult, ok := intercept.CanCatch(hunter, prey)
+	exact := exactResult.Duration
+	require.True(t, ok)
+	linearResult, ok := linear.CanCatch(hunter, prey)
+	linearDuration := linearResult.Duration
+	require.True(t, ok)
+	steppedResult, ok := stepped.CanCatch(hunter, prey)
+	steppedDuration := steppedResult.Duration
+	require.True(t, ok)
+
+	// assert
+	// -> a prey fleeing directly away is the case all models agree on
+	require.InDelta(t, 10.0, exact, 1e-9)
+	require.InDelta(t, exact, linearDuration, 1e-9)
+	require.InDelta(t, exact, steppedDuration, 0.01)
+}
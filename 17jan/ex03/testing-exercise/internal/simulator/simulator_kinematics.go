@@ -0,0 +1,62 @@
+package simulator
+
+import "math"
+
+// TopSpeed returns the top speed of the subject (in m/s)
+func (s *Subject) TopSpeed() (speed float64) {
+	speed = s.Speed
+	if s.MaxSpeed > 0 {
+		speed = s.MaxSpeed
+	}
+	return
+}
+
+// HasKinematics returns true if the subject has acceleration, top speed or stamina,
+// so its speed changes along the simulation
+func (s *Subject) HasKinematics() (ok bool) {
+	ok = s.Kinematics != Kinematics{}
+	return
+}
+
+// Steady returns true if the speed of the subject does not change anymore
+// - it is at the speed it wants to reach, and it does not move above its cruise speed, so its stamina can not run out
+func (s *Subject) Steady() (ok bool) {
+	target := s.TopSpeed()
+	if s.CruiseSpeed > 0 && s.Stamina <= 0 {
+		target = math.Min(target, s.CruiseSpeed)
+	}
+	ok = s.Speed == target && (s.CruiseSpeed <= 0 || s.Speed <= s.CruiseSpeed)
+	return
+}
+
+// Throttle updates the speed and the stamina of the subject after dt seconds
+// - the subject speeds up to its top speed while it has stamina, and slows down to its cruise speed when it runs out
+// - the stamina drains while the subject moves above its cruise speed and recovers while below it
+func (s *Subject) Throttle(dt float64) {
+	// speed the subject wants to reach
+	target := s.TopSpeed()
+	if s.CruiseSpeed > 0 && s.Stamina <= 0 {
+		target = math.Min(target, s.CruiseSpeed)
+	}
+
+	// accelerate (or decelerate) toward the target speed
+	switch {
+	case s.Acceleration <= 0:
+		s.Speed = target
+	case s.Speed < target:
+		s.Speed = math.Min(target, s.Speed+s.Acceleration*dt)
+	case s.Speed > target:
+		s.Speed = math.Max(target, s.Speed-s.Acceleration*dt)
+	}
+
+	// stamina
+	if s.CruiseSpeed <= 0 {
+		return
+	}
+	switch {
+	case s.Speed > s.CruiseSpeed:
+		s.Stamina = math.Max(0, s.Stamina-dt)
+	case s.Speed < s.CruiseSpeed && s.Stamina < s.MaxStamina:
+		s.Stamina = math.Min(s.MaxStamina, s.Stamina+dt)
+	}
+}
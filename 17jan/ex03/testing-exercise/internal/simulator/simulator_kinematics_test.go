@@ -0,0 +1,227 @@
+package simulator_test
+
+import (
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Unit Tests for the kinematics of Subject
+func TestSubject_Throttle(t *testing.T) {
+	type input struct {
+		subject simulator.Subject
+		dt      float64
+	}
+	type output struct {
+		speed   float64
+		stamina float64
+	}
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	cases := []testCase{
+		// case 1: no kinematics - holds its speed
+		{
+			name:   "no kinematics",
+			input:  input{subject: simulator.Subject{Speed: 10}, dt: 1},
+			output: output{speed: 10},
+		},
+		// case 2: no acceleration - reaches its top speed instantly
+		{
+			name:   "top speed without acceleration",
+			input:  input{subject: simulator.Subject{Speed: 0, Kinematics: simulator.Kinematics{MaxSpeed: 20}}, dt: 1},
+			output: output{speed: 20},
+		},
+		// case 3: acceleration - speeds up toward its top speed
+		{
+			name:   "acceleration",
+			input:  input{subject: simulator.Subject{Speed: 0, Kinematics: simulator.Kinematics{MaxSpeed: 20, Acceleration: 5}}, dt: 1},
+			output: output{speed: 5},
+		},
+		// case 4: above cruise speed - drains stamina
+		{
+			name: "sprint drains stamina",
+			input: input{
+				subject: simulator.Subject{Speed: 20, Kinematics: simulator.Kinematics{MaxSpeed: 20, CruiseSpeed: 5, Stamina: 3, MaxStamina: 3}},
+				dt:      1,
+			},
+			output: output{speed: 20, stamina: 2},
+		},
+		// case 5: out of stamina - slows down to cruise speed
+		{
+			name: "exhausted",
+			input: input{
+				subject: simulator.Subject{Speed: 20, Kinematics: simulator.Kinematics{MaxSpeed: 20, CruiseSpeed: 5, Acceleration: 10, Stamina: 0, MaxStamina: 3}},
+				dt:      1,
+			},
+			output: output{speed: 10, stamina: 0},
+		},
+		// case 6: below cruise speed - recovers stamina up to its max
+		{
+			name: "recovering",
+			input: input{
+				subject: simulator.Subject{Speed: 0, Kinematics: simulator.Kinematics{MaxSpeed: 20, CruiseSpeed: 5, Acceleration: 1, Stamina: 2.5, MaxStamina: 3}},
+				dt:      1,
+			},
+			output: output{speed: 1, stamina: 3},
+		},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// act
+			impl := c.input.subject
+			impl.Throttle(c.input.dt)
+
+			// assert
+			require.Equal(t, c.output.speed, impl.Speed)
+			require.Equal(t, c.output.stamina, impl.Stamina)
+		})
+	}
+}
+
+func TestSubject_Steady(t *testing.T) {
+	type testCase struct {
+		name    string
+		subject simulator.Subject
+		steady  bool
+	}
+
+	cases := []testCase{
+		// case 1: no kinematics - holds its speed
+		{name: "no kinematics", subject: simulator.Subject{Speed: 10}, steady: true},
+		// case 2: still speeding up
+		{name: "speeding up", subject: simulator.Subject{Speed: 5, Kinematics: simulator.Kinematics{MaxSpeed: 20, Acceleration: 5}}, steady: false},
+		// case 3: at its top speed, above its cruise speed - it gets tired and slows down
+		{name: "sprinting", subject: simulator.Subject{Speed: 20, Kinematics: simulator.Kinematics{MaxSpeed: 20, CruiseSpeed: 5, Stamina: 3, MaxStamina: 3}}, steady: false},
+		// case 4: exhausted, at its cruise speed
+		{name: "cruising", subject: simulator.Subject{Speed: 5, Kinematics: simulator.Kinematics{MaxSpeed: 20, CruiseSpeed: 5, MaxStamina: 3}}, steady: true},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// act
+			steady := c.subject.Steady()
+
+			// assert
+			require.Equal(t, c.steady, steady)
+		})
+	}
+}
+
+// Tests comparing burst-sprint and endurance subjects in the simulators
+func TestCatchSimulator_Kinematics(t *testing.T) {
+	// a burst-sprint hunter: very fast for 5 seconds, then slower than the prey
+	newHunter := func() *simulator.Subject {
+		return &simulator.Subject{
+			Position:   &positioner.Position{X: 0, Y: 0, Z: 0},
+			Speed:      0,
+			Kinematics: simulator.Kinematics{MaxSpeed: 30, CruiseSpeed: 5, Stamina: 5, MaxStamina: 5},
+		}
+	}
+	// an endurance prey: holds 10 m/s forever
+	newPrey := func(x float64) *simulator.Subject {
+		return &simulator.Subject{Position: &positioner.Position{X: x, Y: 0, Z: 0}, Speed: 10}
+	}
+
+	t.Run("default - the sprint is long enough", func(t *testing.T) {
+		// arrange
+		impl := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+			MaxTimeToCatch: 100,
+			Positioner:     positioner.NewPositionerDefault(),
+			TimeStep:       0.1,
+		})
+
+		// act
+		result, ok := impl.CanCatch(newHunter(), newPrey(50))
+
+		// assert
+		// -> closing at 20 m/s
+		require.True(t, ok)
+		require.InDelta(t, 2.5, result.Duration, 1e-9)
+		require.InDelta(t, 75.0, result.HunterDistance, 1e-9)
+		require.InDelta(t, 25.0, result.PreyDistance, 1e-9)
+	})
+
+	t.Run("default - a hunter that can never gain stops stepping", func(t *testing.T) {
+		// arrange
+		// - so many steps that stepping them all would not end in time
+		impl := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+			MaxTimeToCatch: 1e9,
+			Positioner:     positioner.NewPositionerDefault(),
+			TimeStep:       0.1,
+		})
+		hunter := &simulator.Subject{Position: &positioner.Position{}, Speed: 0, Kinematics: simulator.Kinematics{MaxSpeed: 8, Acceleration: 2}}
+
+		// act
+		type hunt struct {
+			result *simulator.CatchResult
+			ok     bool
+		}
+		done := make(chan hunt, 1)
+		go func() {
+			result, ok := impl.CanCatch(hunter, newPrey(50))
+			done <- hunt{result: result, ok: ok}
+		}()
+
+		// assert
+		// -> it steps only while the hunter speeds up (4 seconds), then the rest of the time at once
+		select {
+		case h := <-done:
+			require.False(t, h.ok)
+			require.Equal(t, simulator.OutcomePreyFaster, h.result.Outcome)
+			require.InDelta(t, 10*1e9, h.result.PreyDistance, 1e-3)
+			require.InDelta(t, 8*1e9-16, h.result.HunterDistance, 1)
+		case <-time.After(time.Second):
+			t.Fatal("the simulator kept stepping")
+		}
+	})
+
+	t.Run("default - the prey outlasts the sprint", func(t *testing.T) {
+		// arrange
+		impl := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+			MaxTimeToCatch: 100,
+			Positioner:     positioner.NewPositionerDefault(),
+			TimeStep:       0.1,
+		})
+
+		// act
+		result, ok := impl.CanCatch(newHunter(), newPrey(150))
+
+		// assert
+		// -> the sprint closes only about 100 meters (one time step more or less)
+		require.False(t, ok)
+		require.Equal(t, 0.0, result.Duration)
+		require.Equal(t, simulator.OutcomeOutOfTime, result.Outcome)
+		require.InDelta(t, 50.0, result.ClosestApproach, 2.0)
+	})
+
+	t.Run("stepped - the prey outlasts the sprint", func(t *testing.T) {
+		// arrange
+		impl := simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 100,
+			Positioner:     positioner.NewPositionerDefault(),
+			TimeStep:       0.1,
+			CaptureRadius:  0.5,
+		})
+
+		// act
+		shortResult, shortOk := impl.CanCatch(newHunter(), newPrey(50))
+		longResult, longOk := impl.CanCatch(newHunter(), newPrey(150))
+
+		// assert
+		require.True(t, shortOk)
+		require.InDelta(t, (50-0.5)/20.0, shortResult.Duration, 1e-9)
+		require.False(t, longOk)
+		require.Equal(t, simulator.OutcomeOutOfTime, longResult.Outcome)
+		require.InDelta(t, 50.0, longResult.ClosestApproach, 2.0)
+	})
+}
@@ -0,0 +1,255 @@
+package simulator
+
+import (
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"testdoubles/internal/positioner"
+)
+
+// defaultHistogramBins is the default number of bins of the histograms of a MonteCarlo summary
+const defaultHistogramBins = 10
+
+// Range is a range of values [Min, Max]
+type Range struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// Volume is the box of positions [Min, Max] on each axis
+type Volume struct {
+	Min positioner.Position `json:"min"`
+	Max positioner.Position `json:"max"`
+}
+
+// Distribution is how a subject of each hunt of a MonteCarlo batch is drawn
+type Distribution struct {
+	// Speed is the range of the speed of the subject (in m/s)
+	Speed Range
+	// Position is the volume where the subject starts
+	Position Volume
+	// Kinematics of the subject (optional)
+	Kinematics Kinematics
+	// Navigator steers the subject (optional)
+	Navigator Navigator
+}
+
+// ConfigMonteCarlo is the configuration for MonteCarlo
+type ConfigMonteCarlo struct {
+	// Simulator runs each hunt (it is shared by the workers)
+	Simulator CatchSimulator
+	// Hunter and Prey are the distributions the subjects of each hunt are drawn from
+	Hunter Distribution
+	Prey   Distribution
+	// Runs is the number of hunts
+	Runs int
+	// Workers is the number of hunts run at the same time (default: the number of CPUs)
+	Workers int
+	// Seed is the seed of the batch: hunt i is drawn from Seed + i
+	Seed int64
+	// Bins is the number of bins of the histograms (default: 10)
+	Bins int
+}
+
+// NewMonteCarlo creates a new MonteCarlo
+func NewMonteCarlo(cfg *ConfigMonteCarlo) (mc *MonteCarlo) {
+	// default config
+	workers := runtime.NumCPU()
+	if cfg.Workers > 0 {
+		workers = cfg.Workers
+	}
+	bins := defaultHistogramBins
+	if cfg.Bins > 0 {
+		bins = cfg.Bins
+	}
+
+	mc = &MonteCarlo{
+		sm:      cfg.Simulator,
+		hunter:  cfg.Hunter,
+		prey:    cfg.Prey,
+		runs:    cfg.Runs,
+		workers: workers,
+		seed:    cfg.Seed,
+		bins:    bins,
+	}
+	return
+}
+
+// MonteCarlo runs a batch of random hunts and summarizes them
+type MonteCarlo struct {
+	// sm is the simulator of each hunt
+	sm CatchSimulator
+	// hunter and prey are the distributions of the subjects
+	hunter Distribution
+	prey   Distribution
+	// runs is the number of hunts
+	runs int
+	// workers is the number of hunts run at the same time
+	workers int
+	// seed of the batch
+	seed int64
+	// bins of the histograms
+	bins int
+}
+
+// Bin is a bin of a histogram: the number of values in [From, To)
+type Bin struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int     `json:"count"`
+}
+
+// Stats is the summary of a set of values
+type Stats struct {
+	Count     int     `json:"count"`
+	Min       float64 `json:"min"`
+	Max       float64 `json:"max"`
+	Mean      float64 `json:"mean"`
+	Median    float64 `json:"median"`
+	P95       float64 `json:"p95"`
+	Histogram []Bin   `json:"histogram"`
+}
+
+// MonteCarloSummary is the summary of a batch of hunts
+type MonteCarloSummary struct {
+	// Runs is the number of hunts
+	Runs int `json:"runs"`
+	// Seed is the seed of the batch
+	Seed int64 `json:"seed"`
+	// CatchProbability is the ratio of hunts where the hunter catches the prey
+	CatchProbability float64 `json:"catch_probability"`
+	// Outcomes is the number of hunts by outcome (a hunt the simulator gives no result for has none)
+	Outcomes map[Outcome]int `json:"outcomes"`
+	// Duration is the time to catch the prey, of the hunts where it is caught (in seconds)
+	Duration Stats `json:"duration"`
+	// ClosestApproach is the closest distance between the subjects, of the hunts where the prey escapes (in meters)
+	ClosestApproach Stats `json:"closest_approach"`
+}
+
+// Run runs the batch of hunts
+// - the summary only depends on the seed, not on the number of workers
+// - a hunt the simulator gives no result for is a miss
+func (m *MonteCarlo) Run() (summary *MonteCarloSummary) {
+	// hunts: each worker writes the result of the hunts it runs by index
+	results := make([]*CatchResult, m.runs)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < m.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = m.hunt(i)
+			}
+		}()
+	}
+	for i := 0; i < m.runs; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	// summary
+	summary = &MonteCarloSummary{Runs: m.runs, Seed: m.seed, Outcomes: make(map[Outcome]int)}
+	var durations, approaches []float64
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		summary.Outcomes[result.Outcome]++
+		if result.Caught() {
+			durations = append(durations, result.Duration)
+			continue
+		}
+		approaches = append(approaches, result.ClosestApproach)
+	}
+	if m.runs > 0 {
+		summary.CatchProbability = float64(len(durations)) / float64(m.runs)
+	}
+	summary.Duration = statsOf(durations, m.bins)
+	summary.ClosestApproach = statsOf(approaches, m.bins)
+	return
+}
+
+// hunt runs the i-th hunt of the batch
+func (m *MonteCarlo) hunt(i int) (result *CatchResult) {
+	rng := rand.New(rand.NewSource(m.seed + int64(i)))
+	hunter := m.hunter.draw(rng)
+	prey := m.prey.draw(rng)
+	result, _ = m.sm.CanCatch(hunter, prey)
+	return
+}
+
+// draw draws a subject from the distribution
+func (d Distribution) draw(rng *rand.Rand) (s *Subject) {
+	between := func(min, max float64) float64 { return min + rng.Float64()*(max-min) }
+
+	s = &Subject{
+		Speed: between(d.Speed.Min, d.Speed.Max),
+		Position: &positioner.Position{
+			X: between(d.Position.Min.X, d.Position.Max.X),
+			Y: between(d.Position.Min.Y, d.Position.Max.Y),
+			Z: between(d.Position.Min.Z, d.Position.Max.Z),
+		},
+		Kinematics: d.Kinematics,
+		Navigator:  d.Navigator,
+	}
+	if s.Kinematics.MaxStamina == 0 {
+		s.Kinematics.MaxStamina = s.Kinematics.Stamina
+	}
+	return
+}
+
+// statsOf returns the summary of a set of values
+// - the median and the p95 use the nearest rank
+func statsOf(values []float64, bins int) (stats Stats) {
+	stats.Count = len(values)
+	stats.Histogram = []Bin{}
+	if len(values) == 0 {
+		return
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	stats.Min = sorted[0]
+	stats.Max = sorted[len(sorted)-1]
+	stats.Mean = sum / float64(len(sorted))
+	stats.Median = percentile(sorted, 0.5)
+	stats.P95 = percentile(sorted, 0.95)
+
+	// histogram: bins of the same width from min to max (the last one includes max)
+	width := (stats.Max - stats.Min) / float64(bins)
+	if width == 0 {
+		stats.Histogram = append(stats.Histogram, Bin{From: stats.Min, To: stats.Max, Count: len(sorted)})
+		return
+	}
+	for b := 0; b < bins; b++ {
+		stats.Histogram = append(stats.Histogram, Bin{From: stats.Min + float64(b)*width, To: stats.Min + float64(b+1)*width})
+	}
+	for _, v := range sorted {
+		b := int((v - stats.Min) / width)
+		if b >= bins {
+			b = bins - 1
+		}
+		stats.Histogram[b].Count++
+	}
+	return
+}
+
+// percentile returns the p-th percentile (0 to 1) of sorted values, by nearest rank
+func percentile(sorted []float64, p float64) (v float64) {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	v = sorted[rank]
+	return
+}
@@ -0,0 +1,132 @@
+package simulator_test
+
+import (
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Unit Tests for MonteCarlo
+func TestMonteCarlo_Run(t *testing.T) {
+	// sm is the simulator of the hunts
+	sm := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+		MaxTimeToCatch: 100,
+		Positioner:     positioner.NewPositionerDefault(),
+	})
+
+	t.Run("fixed subjects - every hunt is the same", func(t *testing.T) {
+		// arrange
+		impl := simulator.NewMonteCarlo(&simulator.ConfigMonteCarlo{
+			Simulator: sm,
+			Hunter:    simulator.Distribution{Speed: simulator.Range{Min: 10, Max: 10}},
+			Prey: simulator.Distribution{
+				Speed:    simulator.Range{Min: 5, Max: 5},
+				Position: simulator.Volume{Min: positioner.Position{X: 100}, Max: positioner.Position{X: 100}},
+			},
+			Runs: 50,
+			Seed: 1,
+		})
+
+		// act
+		summary := impl.Run()
+
+		// assert
+		expectedDuration := simulator.Stats{
+			Count: 50, Min: 20, Max: 20, Mean: 20, Median: 20, P95: 20,
+			Histogram: []simulator.Bin{{From: 20, To: 20, Count: 50}},
+		}
+		require.Equal(t, 50, summary.Runs)
+		require.Equal(t, 1.0, summary.CatchProbability)
+		require.Equal(t, map[simulator.Outcome]int{simulator.OutcomeCaught: 50}, summary.Outcomes)
+		require.Equal(t, expectedDuration, summary.Duration)
+		require.Equal(t, 0, summary.ClosestApproach.Count)
+	})
+
+	t.Run("no result - every hunt is a miss", func(t *testing.T) {
+		// arrange
+		// - simulator: mock
+		mk := simulator.NewCatchSimulatorMock()
+		mk.CanCatchFunc = func(hunter, prey *simulator.Subject) (result *simulator.CatchResult, ok bool) { return nil, false }
+		impl := simulator.NewMonteCarlo(&simulator.ConfigMonteCarlo{
+			Simulator: mk,
+			Hunter:    simulator.Distribution{Speed: simulator.Range{Min: 10, Max: 10}},
+			Prey:      simulator.Distribution{Speed: simulator.Range{Min: 5, Max: 5}},
+			Runs:      10,
+			// - one worker: the mock counts its calls without a lock
+			Workers: 1,
+			Seed:    1,
+		})
+
+		// act
+		summary := impl.Run()
+
+		// assert
+		require.Equal(t, 10, summary.Runs)
+		require.Equal(t, 0.0, summary.CatchProbability)
+		require.Empty(t, summary.Outcomes)
+		require.Equal(t, 10, mk.Calls.CanCatch)
+		require.Equal(t, 0, summary.Duration.Count)
+		require.Equal(t, 0, summary.ClosestApproach.Count)
+	})
+
+	t.Run("prey always faster - no hunt is caught", func(t *testing.T) {
+		// arrange
+		impl := simulator.NewMonteCarlo(&simulator.ConfigMonteCarlo{
+			Simulator: sm,
+			Hunter:    simulator.Distribution{Speed: simulator.Range{Min: 1, Max: 5}},
+			Prey: simulator.Distribution{
+				Speed:    simulator.Range{Min: 10, Max: 20},
+				Position: simulator.Volume{Min: positioner.Position{X: 10}, Max: positioner.Position{X: 100}},
+			},
+			Runs: 100,
+			Seed: 1,
+		})
+
+		// act
+		summary := impl.Run()
+
+		// assert
+		require.Equal(t, 0.0, summary.CatchProbability)
+		require.Equal(t, map[simulator.Outcome]int{simulator.OutcomePreyFaster: 100}, summary.Outcomes)
+		require.Equal(t, 100, summary.ClosestApproach.Count)
+		require.GreaterOrEqual(t, summary.ClosestApproach.Min, 10.0)
+		require.LessOrEqual(t, summary.ClosestApproach.Max, 100.0)
+	})
+
+	t.Run("same seed - same summary whatever the number of workers", func(t *testing.T) {
+		// arrange
+		cfg := simulator.ConfigMonteCarlo{
+			Simulator: sm,
+			Hunter: simulator.Distribution{
+				Speed:    simulator.Range{Min: 15, Max: 159},
+				Position: simulator.Volume{Max: positioner.Position{X: 500, Y: 500, Z: 500}},
+			},
+			Prey: simulator.Distribution{
+				Speed:    simulator.Range{Min: 15, Max: 267},
+				Position: simulator.Volume{Max: positioner.Position{X: 500, Y: 500, Z: 500}},
+			},
+			Runs: 500,
+			Seed: 42,
+			Bins: 5,
+		}
+		cfg1, cfg8 := cfg, cfg
+		cfg1.Workers, cfg8.Workers = 1, 8
+
+		// act
+		summary1 := simulator.NewMonteCarlo(&cfg1).Run()
+		summary8 := simulator.NewMonteCarlo(&cfg8).Run()
+
+		// assert
+		require.Equal(t, summary1, summary8)
+		require.Len(t, summary1.Duration.Histogram, 5)
+		count := 0
+		for _, bin := range summary1.Duration.Histogram {
+			count += bin.Count
+		}
+		require.Equal(t, summary1.Duration.Count, count)
+		require.InDelta(t, float64(summary1.Duration.Count)/500, summary1.CatchProbability, 1e-9)
+		require.LessOrEqual(t, summary1.Duration.Median, summary1.Duration.P95)
+	})
+}
@@ -0,0 +1,47 @@
+package simulator
+
+import (
+	"math"
+	"testdoubles/internal/positioner"
+)
+
+// Perception is how a subject senses the other subject
+// - only the stepped simulator takes it into account, the other simulators sense the exact position
+type Perception struct {
+	// Radius is the max distance the subject senses at in clear water (in meters), zero means no limit
+	Radius float64
+	// FieldOfView is the angle of the cone the subject senses in front of it (in radians), zero means all around
+	FieldOfView float64
+	// Noise is the error of the sensed position per meter of distance in clear water (standard deviation)
+	Noise float64
+}
+
+// Sense returns where the subject senses the other one
+// - murkiness: is how murky the water is (zero is clear water), it divides the radius and multiplies the noise by 1+murkiness
+// - normal: returns normally distributed random numbers for the noise (nil for no noise)
+// - ok: is false if the other subject is too far or out of the field of view
+func (p *Perception) Sense(self, other *Subject, murkiness float64, normal func() float64) (position *positioner.Position, ok bool) {
+	r := other.Position.Sub(*self.Position)
+	distance := r.Length()
+	murk := 1 + math.Max(0, murkiness)
+
+	// - too far
+	if p.Radius > 0 && distance > p.Radius/murk {
+		return
+	}
+	// - out of the field of view: the cone goes along the heading of the subject (all around if it has none)
+	if p.FieldOfView > 0 && self.Heading != nil && distance > 0 {
+		heading := self.Heading.Normalize()
+		if heading.Length() > 0 && math.Acos(math.Max(-1, math.Min(1, heading.Dot(r)/distance))) > p.FieldOfView/2 {
+			return
+		}
+	}
+
+	sensed := *other.Position
+	if normal != nil && p.Noise > 0 {
+		sigma := p.Noise * distance * murk
+		sensed = sensed.Add(positioner.Position{X: normal() * sigma, Y: normal() * sigma, Z: normal() * sigma})
+	}
+	position, ok = &sensed, true
+	return
+}
@@ -0,0 +1,163 @@
+package simulator_test
+
+import (
+	"math"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for Perception
+func TestPerception_Sense(t *testing.T) {
+	type input struct {
+		other     *positioner.Position
+		murkiness float64
+		normal    func() float64
+	}
+	type output struct {
+		position *positioner.Position
+		ok       bool
+	}
+	type testCase struct {
+		name       string
+		perception *simulator.Perception
+		input      input
+		output     output
+	}
+
+	// the subject is at the origin heading along X
+	self := &simulator.Subject{Position: &positioner.Position{}, Heading: &positioner.Position{X: 1}}
+	one := func() float64 { return 1 }
+
+	cases := []testCase{
+		// case 1: inside the radius
+		{
+			name:       "inside the radius",
+			perception: &simulator.Perception{Radius: 100},
+			input:      input{other: &positioner.Position{X: 80}},
+			output:     output{position: &positioner.Position{X: 80}, ok: true},
+		},
+		// case 2: out of the radius
+		{
+			name:       "out of the radius",
+			perception: &simulator.Perception{Radius: 100},
+			input:      input{other: &positioner.Position{X: 120}},
+			output:     output{},
+		},
+		// case 3: the murky water shortens the radius
+		{
+			name:       "murky water",
+			perception: &simulator.Perception{Radius: 100},
+			input:      input{other: &positioner.Position{X: 80}, murkiness: 1},
+			output:     output{},
+		},
+		// case 4: out of the field of view (behind the subject)
+		{
+			name:       "out of the field of view",
+			perception: &simulator.Perception{FieldOfView: math.Pi / 2},
+			input:      input{other: &positioner.Position{X: -10}},
+			output:     output{},
+		},
+		// case 5: inside the field of view
+		{
+			name:       "inside the field of view",
+			perception: &simulator.Perception{FieldOfView: math.Pi / 2},
+			input:      input{other: &positioner.Position{X: 10, Y: 9}},
+			output:     output{position: &positioner.Position{X: 10, Y: 9}, ok: true},
+		},
+		// case 6: the noise grows with the distance and the murkiness
+		{
+			name:       "noise",
+			perception: &simulator.Perception{Noise: 0.1},
+			input:      input{other: &positioner.Position{X: 50}, murkiness: 1, normal: one},
+			output:     output{position: &positioner.Position{X: 60, Y: 10, Z: 10}, ok: true},
+		},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// act
+			other := &simulator.Subject{Position: c.input.other}
+			position, ok := c.perception.Sense(self, other, c.input.murkiness, c.input.normal)
+
+			// assert
+			require.Equal(t, c.output.ok, ok)
+			require.Equal(t, c.output.position, position)
+		})
+	}
+}
+
+func TestCatchSimulatorStepped_CanCatch_Perception(t *testing.T) {
+	// a still prey 100 meters away from the hunter
+	prey := &simulator.Subject{Speed: 0, Position: &positioner.Position{X: 100, Y: 0, Z: 0}}
+	hunt := func(perception *simulator.Perception, murkiness float64) (result *simulator.CatchResult, ok bool) {
+		impl := simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 30,
+			Positioner:     positioner.NewPositionerDefault(),
+			Murkiness:      murkiness,
+			Seed:           1,
+		})
+		hunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{}, Perception: perception}
+		result, ok = impl.CanCatch(hunter, prey)
+		return
+	}
+
+	t.Run("the hunter senses the prey and catches it", func(t *testing.T) {
+		// act
+		result, ok := hunt(&simulator.Perception{Radius: 150, Noise: 0.01}, 0)
+
+		// assert
+		require.True(t, ok)
+		require.Equal(t, simulator.OutcomeCaught, result.Outcome)
+	})
+
+	t.Run("the prey is too far to be sensed", func(t *testing.T) {
+		// act
+		result, ok := hunt(&simulator.Perception{Radius: 50}, 0)
+
+		// assert
+		require.False(t, ok)
+		require.Equal(t, simulator.OutcomeNotDetected, result.Outcome)
+		require.ErrorIs(t, result.Outcome.Err(), simulator.ErrNotDetected)
+		require.Equal(t, 0.0, result.HunterDistance)
+	})
+
+	t.Run("the murky water hides the prey", func(t *testing.T) {
+		// act
+		result, ok := hunt(&simulator.Perception{Radius: 150}, 1)
+
+		// assert
+		require.False(t, ok)
+		require.Equal(t, simulator.OutcomeNotDetected, result.Outcome)
+	})
+
+	t.Run("the hunter loses contact and searches the last known position", func(t *testing.T) {
+		// arrange
+		// - the prey flees along Y from 5 meters in front of the hunter, out of its narrow field of view
+		impl := simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 10,
+			TimeStep:       0.1,
+			Positioner:     positioner.NewPositionerDefault(),
+		})
+		hunter := &simulator.Subject{
+			Speed:      10,
+			Position:   &positioner.Position{},
+			Heading:    &positioner.Position{X: 1},
+			Perception: &simulator.Perception{FieldOfView: math.Pi / 18},
+		}
+		fleeing := &simulator.Subject{Speed: 20, Position: &positioner.Position{X: 5}, Heading: &positioner.Position{Y: 1}}
+
+		// act
+		result, ok := impl.CanCatch(hunter, fleeing)
+
+		// assert
+		require.False(t, ok)
+		require.NotEqual(t, simulator.OutcomeNotDetected, result.Outcome)
+		// - it went to where it last sensed the prey and kept going on
+		require.InDelta(t, 0.0, result.HunterPosition.Y, 1e-9)
+		require.Greater(t, result.HunterPosition.X, 5.0)
+	})
+}
@@ -0,0 +1,347 @@
+package simulator
+
+import (
+	"math"
+	"math/rand"
+	"testdoubles/internal/environment"
+	"testdoubles/internal/positioner"
+)
+
+const (
+	// defaultTimeStep is the default time step of the stepped simulation (in seconds)
+	defaultTimeStep = 0.1
+	// defaultCaptureRadius is the default distance at which the hunter catches the prey (in meters)
+	defaultCaptureRadius = 1.0
+)
+
+// ConfigCatchSimulatorStepped is the configuration for CatchSimulatorStepped
+type ConfigCatchSimulatorStepped struct {
+	// MaxTimeToCatch is the max time to catch the prey (in seconds)
+	MaxTimeToCatch float64
+	// TimeStep is the time that passes on each step of the simulation (in seconds)
+	TimeStep float64
+	// CaptureRadius is the distance at which the hunter catches the prey (in meters)
+	// - a hunter with a capture radius of its own catches from it instead
+	CaptureRadius float64
+	// Positioner is used to calculate the distance between the hunter and the prey
+	Positioner positioner.Positioner
+	// Record makes the simulator record the trajectory of the subjects on each step
+	Record bool
+	// Recorder also records the trajectory of the subjects (nil for none), whether Record is set or not
+	Recorder TrajectoryRecorder
+	// Arena keeps the subjects inside its bounds on each step (nil to have no bounds)
+	Arena *positioner.Arena
+	// Murkiness is how murky the water is for the perception of the hunter (zero is clear water)
+	Murkiness float64
+	// Seed is the seed of the noise of the perception of the hunter, so the same seed replays the same hunt
+	Seed int64
+	// Current carries the subjects along on each step, as much as their drag says (nil for still water)
+	Current environment.Current
+}
+
+// NewCatchSimulatorStepped creates a new CatchSimulatorStepped
+func NewCatchSimulatorStepped(cfg *ConfigCatchSimulatorStepped) (sm *CatchSimulatorStepped) {
+	// default config
+	timeStep := defaultTimeStep
+	if cfg.TimeStep > 0 {
+		timeStep = cfg.TimeStep
+	}
+	captureRadius := defaultCaptureRadius
+	if cfg.CaptureRadius > 0 {
+		captureRadius = cfg.CaptureRadius
+	}
+
+	sm = &CatchSimulatorStepped{
+		maxTimeToCatch: cfg.MaxTimeToCatch,
+		timeStep:       timeStep,
+		captureRadius:  captureRadius,
+		ps:             cfg.Positioner,
+		record:         cfg.Record,
+		recorder:       cfg.Recorder,
+		arena:          cfg.Arena,
+		murkiness:      cfg.Murkiness,
+		seed:           cfg.Seed,
+		current:        cfg.Current,
+	}
+	return
+}
+
+// CatchSimulatorStepped is an implementation of CatchSimulator that moves the hunter and the prey
+// in small time steps in the 3D space
+// - the hunter steers toward the current position of the prey on each step (or as its navigator says)
+// - the hunter goes around the obstacles if the positioner is a positioner.Router
+// - the prey runs along its heading (or directly away from the hunter if it has none), unless its navigator says otherwise
+// - both subjects speed up and slow down following their acceleration, top speed and stamina
+// - both subjects are kept inside the arena, if any, as its boundary mode says
+// - the current, if any, carries both subjects along as much as their drag says
+// - a hunter with perception only chases the prey it senses, and searches the last position it sensed when it loses contact
+type CatchSimulatorStepped struct {
+	// max time to catch the prey in seconds
+	maxTimeToCatch float64
+	// time step of the simulation in seconds
+	timeStep float64
+	// distance at which the hunter catches the prey in meters
+	captureRadius float64
+	// positioner: used to calculate the distance between the hunter and the prey
+	ps positioner.Positioner
+	// record the trajectory of the subjects
+	record bool
+	// recorder also records the trajectory of the subjects
+	recorder TrajectoryRecorder
+	// arena where the subjects move
+	arena *positioner.Arena
+	// murkiness of the water
+	murkiness float64
+	// seed of the noise of the perception
+	seed int64
+	// current of the water
+	current environment.Current
+}
+
+// CanCatch returns true if the hunter can catch the prey
+func (c *CatchSimulatorStepped) CanCatch(hunter, prey *Subject) (result *CatchResult, ok bool) {
+	// copy the subjects, so they are not moved by the simulation
+	h, p := *hunter, *prey
+	hunterPosition, preyPosition := *hunter.Position, *prey.Position
+	h.Position, p.Position = &hunterPosition, &preyPosition
+
+	// heading of the prey: its own or directly away from the hunter
+	preyHeading := preyPosition.Sub(hunterPosition)
+	if prey.Heading != nil {
+		preyHeading = *prey.Heading
+	}
+	p.Heading = &preyHeading
+	// heading of the hunter: its own or directly toward the prey
+	hunterHeading := preyPosition.Sub(hunterPosition)
+	if hunter.Heading != nil {
+		hunterHeading = *hunter.Heading
+	} else if hunter.Perception != nil {
+		// - a hunter that has to sense the prey waits until it does
+		hunterHeading = positioner.Position{}
+	}
+	h.Heading = &hunterHeading
+
+	// the hunter catches the prey from its own distance, if it has one
+	captureRadius := c.captureRadius
+	if hunter.CaptureRadius > 0 {
+		captureRadius = hunter.CaptureRadius
+	}
+
+	// the hunter tracks the prey with its perception (or always knows where it is)
+	normal := rand.New(rand.NewSource(c.seed)).NormFloat64
+	detected, searching := hunter.Perception == nil, false
+	var lastKnown positioner.Position
+
+	distance := c.ps.GetLinearDistance(h.Position, p.Position)
+	result = &CatchResult{ClosestApproach: distance, HunterPosition: &hunterPosition, PreyPosition: &preyPosition}
+	if c.record {
+		result.Trajectory = NewTrajectory()
+	}
+	for step := 0; ; step++ {
+		elapsed := float64(step) * c.timeStep
+		h.Time, p.Time = elapsed, elapsed
+		if result.Trajectory != nil {
+			result.Trajectory.Record(&h, &p)
+		}
+		if c.recorder != nil {
+			c.recorder.Record(&h, &p)
+		}
+
+		// check if the hunter is close enough to catch the prey
+		// - the path to the prey is found once per step: it gives the gap and the way to the prey
+		gap, toPrey := c.routeTo(hunterPosition, preyPosition)
+		result.ClosestApproach = math.Min(result.ClosestApproach, gap)
+		if gap <= captureRadius {
+			result.Outcome = OutcomeCaught
+			result.Duration = elapsed
+			ok = true
+			return
+		}
+		if elapsed >= c.maxTimeToCatch {
+			result.Outcome = outcomeOf(hunter, prey, distance, c.maxTimeToCatch)
+			if !detected {
+				result.Outcome = OutcomeNotDetected
+			}
+			return
+		}
+		dt := math.Min(c.timeStep, c.maxTimeToCatch-elapsed)
+
+		// both subjects steer looking at the other one as it was at the start of the step
+		hunterSnapshot, preySnapshot := snapshot(&h), snapshot(&p)
+		// - the hunter steers toward the prey, around the obstacles (or as its navigator says)
+		if h.Perception == nil {
+			hunterHeading = Steer(hunterSnapshot, preySnapshot, toPrey)
+		} else if sensed, seen := c.sense(&h, &p, normal); seen {
+			// - it steers toward where it senses the prey
+			detected, searching, lastKnown = true, true, *sensed
+			target := snapshot(&p)
+			target.Position = sensed
+			hunterHeading = Steer(hunterSnapshot, target, c.route(hunterPosition, lastKnown))
+		} else if searching {
+			// - it lost contact: it searches the last position it sensed, until it gets there
+			searching = lastKnown.Sub(hunterPosition).Length() > captureRadius
+			if searching {
+				hunterHeading = c.route(hunterPosition, lastKnown)
+			}
+		}
+		// - otherwise (never sensed or already searched) the hunter keeps its heading
+		// - the prey keeps its heading (or evades as its navigator says)
+		preyHeading = Steer(preySnapshot, hunterSnapshot, preyHeading)
+
+		// both subjects speed up or slow down
+		h.Throttle(dt)
+		p.Throttle(dt)
+		hunterVelocity := c.drift(hunterPosition, h.Drag, hunterHeading.Normalize().Scale(h.Speed))
+		preyVelocity := c.drift(preyPosition, p.Drag, preyHeading.Normalize().Scale(p.Speed))
+		h.Velocity, p.Velocity = hunterVelocity, preyVelocity
+
+		// check if the capture happens in the middle of the step
+		r, v := preyPosition.Sub(hunterPosition), preyVelocity.Sub(hunterVelocity)
+		if t, caught := FirstContact(r, v, captureRadius, dt); caught {
+			move(result, hunterVelocity, preyVelocity, t)
+			h.Time, p.Time = elapsed+t, elapsed+t
+			if result.Trajectory != nil && t > 0 {
+				result.Trajectory.Record(&h, &p)
+			}
+			if c.recorder != nil && t > 0 {
+				c.recorder.Record(&h, &p)
+			}
+			result.Outcome = OutcomeCaught
+			result.Duration = elapsed + t
+			result.ClosestApproach = math.Min(result.ClosestApproach, captureRadius)
+			ok = true
+			return
+		}
+		result.ClosestApproach = math.Min(result.ClosestApproach, closestApproach(r, v, dt))
+
+		// move both subjects
+		move(result, hunterVelocity, preyVelocity, dt)
+		if c.arena != nil {
+			hunterPosition, hunterHeading = c.arena.Constrain(hunterPosition, hunterHeading)
+			preyPosition, preyHeading = c.arena.Constrain(preyPosition, preyHeading)
+		}
+	}
+}
+
+// route returns the heading to go from one position to another
+// - around the obstacles if the positioner knows the path, otherwise straight
+func (c *CatchSimulatorStepped) route(from, to positioner.Position) (heading positioner.Position) {
+	heading = to.Sub(from)
+	rt, ok := c.ps.(positioner.Router)
+	if !ok {
+		return
+	}
+	if path, found := rt.Path(&from, &to); found && len(path) > 1 {
+		heading = path[1].Sub(from)
+	}
+	return
+}
+
+// routeTo returns the distance from one position to another and the heading to go there
+// - a Router finds the path once for both: its length and its first leg, otherwise the distance of the positioner and straight
+func (c *CatchSimulatorStepped) routeTo(from, to positioner.Position) (distance float64, heading positioner.Position) {
+	heading = to.Sub(from)
+	rt, ok := c.ps.(positioner.Router)
+	if !ok {
+		distance = c.ps.GetLinearDistance(&from, &to)
+		return
+	}
+	path, found := rt.Path(&from, &to)
+	if !found {
+		distance = math.Inf(1)
+		return
+	}
+	for i := 1; i < len(path); i++ {
+		distance += path[i].Sub(path[i-1]).Length()
+	}
+	if len(path) > 1 {
+		heading = path[1].Sub(from)
+	}
+	return
+}
+
+// drift returns the velocity of a subject over the ground: its own velocity plus the current that carries it
+func (c *CatchSimulatorStepped) drift(position positioner.Position, drag float64, velocity positioner.Velocity) (drifted positioner.Velocity) {
+	drifted = velocity
+	if c.current == nil || drag == 0 {
+		return
+	}
+	drifted = velocity.Add(c.current.At(position).Scale(drag))
+	return
+}
+
+// sense returns where the hunter senses the prey, if it does
+// - the hunter can not sense the prey behind an obstacle if the positioner knows the line of sight
+func (c *CatchSimulatorStepped) sense(hunter, prey *Subject, normal func() float64) (position *positioner.Position, ok bool) {
+	if ls, isLineOfSight := c.ps.(positioner.LineOfSight); isLineOfSight && !ls.HasLineOfSight(hunter.Position, prey.Position) {
+		return
+	}
+	position, ok = hunter.Perception.Sense(hunter, prey, c.murkiness, normal)
+	return
+}
+
+// move moves the subjects to their final positions in the result with their velocities during dt seconds
+func move(result *CatchResult, hunterVelocity, preyVelocity positioner.Position, dt float64) {
+	*result.HunterPosition = result.HunterPosition.Add(hunterVelocity.Scale(dt))
+	*result.PreyPosition = result.PreyPosition.Add(preyVelocity.Scale(dt))
+	result.HunterDistance += hunterVelocity.Length() * dt
+	result.PreyDistance += preyVelocity.Length() * dt
+}
+
+// snapshot returns a copy of the current state of a subject
+func snapshot(s *Subject) (c *Subject) {
+	position, heading := *s.Position, *s.Heading
+	c = &Subject{}
+	*c = *s
+	c.Position, c.Heading = &position, &heading
+	return
+}
+
+// Steer returns the heading of the subject given by its navigator, or the fallback heading
+// if it has no navigator or the navigator keeps the default behaviour
+func Steer(self, other *Subject, fallback positioner.Position) (heading positioner.Position) {
+	heading = fallback
+	if self.Navigator == nil {
+		return
+	}
+	if h := self.Navigator.Heading(self, other); h != nil {
+		heading = *h
+	}
+	return
+}
+
+// FirstContact returns the earliest time in [0, dt] at which the relative position
+// r + v*t is within radius of the origin
+func FirstContact(r, v positioner.Position, radius, dt float64) (t float64, ok bool) {
+	// |r + v*t|^2 = radius^2 -> a*t^2 + b*t + c = 0
+	a := v.Dot(v)
+	b := 2 * r.Dot(v)
+	c := r.Dot(r) - radius*radius
+	if c <= 0 {
+		ok = true
+		return
+	}
+	if a == 0 {
+		return
+	}
+	disc := b*b - 4*a*c
+	if disc < 0 {
+		return
+	}
+	t = (-b - math.Sqrt(disc)) / (2 * a)
+	ok = t >= 0 && t <= dt
+	if !ok {
+		t = 0
+	}
+	return
+}
+
+// closestApproach returns the shortest length of the relative position r + v*t for t in [0, dt]
+func closestApproach(r, v positioner.Position, dt float64) (distance float64) {
+	t := 0.0
+	if vv := v.Dot(v); vv > 0 {
+		t = math.Max(0, math.Min(dt, -r.Dot(v)/vv))
+	}
+	distance = r.Add(v.Scale(t)).Length()
+	return
+}
@@ -0,0 +1,299 @@
+package simulator_test
+
+import (
+	"math"
+	"testdoubles/internal/environment"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Unit Tests for CatchSimulatorStepped
+func TestCatchSimulatorStepped_CanCatch(t *testing.T) {
+	t.Run("Hunter can catch the prey - prey flees directly away", func(t *testing.T) {
+		// arrange
+		cfgImpl := &simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 100,
+			TimeStep:       0.1,
+			CaptureRadius:  0.5,
+			Positioner:     positioner.NewPositionerDefault(),
+		}
+		impl := simulator.NewCatchSimulatorStepped(cfgImpl)
+
+		// act
+		inputHunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
+		inputPrey := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 100, Y: 0, Z: 0}}
+		result, ok := impl.CanCatch(inputHunter, inputPrey)
+
+		// assert
+		// -> same as the linear model, minus the capture radius: (100 - 0.5) / (10 - 5)
+		expectedDuration := 19.9
+		expectedOk := true
+		require.Equal(t, simulator.OutcomeCaught, result.Outcome)
+		require.InDelta(t, expectedDuration, result.Duration, 1e-9)
+		require.InDelta(t, 199.0, result.HunterDistance, 1e-9)
+		require.InDelta(t, 99.5, result.PreyDistance, 1e-9)
+		require.InDelta(t, 199.0, result.HunterPosition.X, 1e-9)
+		require.InDelta(t, 199.5, result.PreyPosition.X, 1e-9)
+		require.InDelta(t, 0.5, result.ClosestApproach, 1e-9)
+		require.Equal(t, expectedOk, ok)
+		// -> the subjects are not moved
+		require.Equal(t, &positioner.Position{X: 0, Y: 0, Z: 0}, inputHunter.Position)
+		require.Equal(t, &positioner.Position{X: 100, Y: 0, Z: 0}, inputPrey.Position)
+	})
+
+	t.Run("Hunter can catch the prey - from a capture radius of its own", func(t *testing.T) {
+		// arrange
+		cfgImpl := &simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 100,
+			TimeStep:       0.1,
+			CaptureRadius:  0.5,
+			Positioner:     positioner.NewPositionerDefault(),
+		}
+		impl := simulator.NewCatchSimulatorStepped(cfgImpl)
+
+		// act
+		inputHunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}, CaptureRadius: 2.5}
+		inputPrey := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 100, Y: 0, Z: 0}}
+		result, ok := impl.CanCatch(inputHunter, inputPrey)
+
+		// assert
+		// -> (100 - 2.5) / (10 - 5)
+		require.True(t, ok)
+		require.InDelta(t, 19.5, result.Duration, 1e-9)
+		require.InDelta(t, 2.5, result.ClosestApproach, 1e-9)
+	})
+
+	t.Run("Hunter can catch the prey - prey runs perpendicular", func(t *testing.T) {
+		// arrange
+		cfgImpl := &simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 100,
+			TimeStep:       0.01,
+			CaptureRadius:  0.01,
+			Positioner:     positioner.NewPositionerDefault(),
+		}
+		impl := simulator.NewCatchSimulatorStepped(cfgImpl)
+
+		// act
+		inputHunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
+		inputPrey := &simulator.Subject{
+			Speed:    5,
+			Position: &positioner.Position{X: 100, Y: 0, Z: 0},
+			Heading:  &positioner.Position{X: 0, Y: 1, Z: 0},
+		}
+		result, ok := impl.CanCatch(inputHunter, inputPrey)
+
+		// assert
+		// -> pure pursuit of a prey running perpendicular: d * vh / (vh^2 - vp^2)
+		expectedDuration := 100.0 * 10.0 / (10.0*10.0 - 5.0*5.0)
+		expectedOk := true
+		require.InDelta(t, expectedDuration, result.Duration, 0.05)
+		require.Equal(t, expectedOk, ok)
+	})
+
+	t.Run("Hunter can not catch the prey - out of time", func(t *testing.T) {
+		// arrange
+		cfgImpl := &simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 15,
+			Positioner:     positioner.NewPositionerDefault(),
+		}
+		impl := simulator.NewCatchSimulatorStepped(cfgImpl)
+
+		// act
+		inputHunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
+		inputPrey := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 100, Y: 0, Z: 0}}
+		result, ok := impl.CanCatch(inputHunter, inputPrey)
+
+		// assert
+		expectedDuration := 0.0
+		expectedOk := false
+		require.Equal(t, simulator.OutcomeOutOfTime, result.Outcome)
+		require.Equal(t, expectedDuration, result.Duration)
+		require.InDelta(t, 25.0, result.ClosestApproach, 1e-9)
+		require.Equal(t, expectedOk, ok)
+	})
+
+	t.Run("Hunter can not catch the prey - hunter slower", func(t *testing.T) {
+		// arrange
+		cfgImpl := &simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 100,
+			Positioner:     positioner.NewPositionerDefault(),
+		}
+		impl := simulator.NewCatchSimulatorStepped(cfgImpl)
+
+		// act
+		inputHunter := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
+		inputPrey := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 100}}
+		result, ok := impl.CanCatch(inputHunter, inputPrey)
+
+		// assert
+		expectedDuration := 0.0
+		expectedOk := false
+		require.Equal(t, simulator.OutcomePreyFaster, result.Outcome)
+		require.Equal(t, expectedDuration, result.Duration)
+		require.Equal(t, 100.0, result.ClosestApproach)
+		require.Equal(t, expectedOk, ok)
+	})
+}
+
+func TestCatchSimulatorStepped_CanCatch_Arena(t *testing.T) {
+	// the prey flees toward a wall 50 meters away: it would escape in an open sea
+	hunter := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
+	prey := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 20, Y: 0, Z: 0}}
+
+	t.Run("open sea - the prey escapes", func(t *testing.T) {
+		// arrange
+		impl := simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 30,
+			Positioner:     positioner.NewPositionerDefault(),
+		})
+
+		// act
+		result, ok := impl.CanCatch(hunter, prey)
+
+		// assert
+		require.False(t, ok)
+		require.Equal(t, simulator.OutcomePreyFaster, result.Outcome)
+	})
+
+	t.Run("clamp - the prey is cornered against the wall", func(t *testing.T) {
+		// arrange
+		impl := simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 30,
+			Positioner:     positioner.NewPositionerDefault(),
+			Arena: positioner.NewArena(positioner.ConfigArena{
+				X: positioner.Bounds{Min: -50, Max: 50},
+				Y: positioner.Bounds{Min: -50, Max: 50},
+				Z: positioner.Bounds{Min: -50, Max: 50},
+			}),
+		})
+
+		// act
+		result, ok := impl.CanCatch(hunter, prey)
+
+		// assert
+		require.True(t, ok)
+		require.Equal(t, 50.0, result.PreyPosition.X)
+		require.InDelta(t, 9.8, result.Duration, 1e-9)
+	})
+}
+
+func TestCatchSimulatorStepped_CanCatch_Obstacles(t *testing.T) {
+	// a wall between the hunter and a still prey, with a gap at the top of Y
+	hunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 10, Y: 10, Z: 5}}
+	prey := &simulator.Subject{Speed: 0, Position: &positioner.Position{X: 90, Y: 10, Z: 5}}
+	arena := positioner.NewArena(positioner.ConfigArena{
+		X: positioner.Bounds{Min: 0, Max: 100},
+		Y: positioner.Bounds{Min: 0, Max: 100},
+		Z: positioner.Bounds{Min: 0, Max: 10},
+	})
+	wall := positioner.NewObstacleBox(positioner.Position{X: 45, Y: -10, Z: -10}, positioner.Position{X: 55, Y: 80, Z: 20})
+
+	t.Run("the hunter routes around the wall", func(t *testing.T) {
+		// arrange
+		impl := simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 60,
+			Positioner: positioner.NewPositionerObstacles(positioner.ConfigPositionerObstacles{
+				Arena:     arena,
+				Obstacles: []positioner.Obstacle{wall},
+				CellSize:  5,
+			}),
+			Arena: arena,
+		})
+
+		// act
+		result, ok := impl.CanCatch(hunter, prey)
+
+		// assert
+		require.True(t, ok)
+		require.Equal(t, simulator.OutcomeCaught, result.Outcome)
+		// - at least over the corners of the wall
+		require.Greater(t, result.HunterDistance, 2*math.Hypot(35, 70)-1)
+	})
+
+	t.Run("the path is found once per step", func(t *testing.T) {
+		// arrange
+		ps := &pathCounter{PositionerObstacles: positioner.NewPositionerObstacles(positioner.ConfigPositionerObstacles{
+			Arena:     arena,
+			Obstacles: []positioner.Obstacle{wall},
+			CellSize:  5,
+		})}
+		impl := simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 60,
+			Positioner:     ps,
+			Record:         true,
+			Arena:          arena,
+		})
+
+		// act
+		result, ok := impl.CanCatch(hunter, prey)
+
+		// assert
+		// - one path per recorded step, and one for the distance at the start
+		require.True(t, ok)
+		require.LessOrEqual(t, ps.paths, len(result.Trajectory.Hunter)+1)
+	})
+}
+
+// pathCounter is a positioner around obstacles that counts the paths it is asked for
+type pathCounter struct {
+	*positioner.PositionerObstacles
+	paths int
+}
+
+// GetLinearDistance counts the path of the distance
+func (p *pathCounter) GetLinearDistance(from, to *positioner.Position) float64 {
+	p.paths++
+	return p.PositionerObstacles.GetLinearDistance(from, to)
+}
+
+// Path counts the path
+func (p *pathCounter) Path(from, to *positioner.Position) ([]positioner.Position, bool) {
+	p.paths++
+	return p.PositionerObstacles.Path(from, to)
+}
+
+func TestCatchSimulatorStepped_CanCatch_Current(t *testing.T) {
+	// the prey flees along X from a hunter 100 meters behind, in a current of 2 m/s
+	hunt := func(current positioner.Velocity, drag float64) (result *simulator.CatchResult, ok bool) {
+		impl := simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 100,
+			TimeStep:       0.1,
+			Positioner:     positioner.NewPositionerDefault(),
+			Current:        environment.NewCurrentConstant(current),
+		})
+		hunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{}, Drag: drag}
+		prey := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 100}, Drag: drag / 2}
+		result, ok = impl.CanCatch(hunter, prey)
+		return
+	}
+
+	t.Run("still water", func(t *testing.T) {
+		// act
+		result, ok := hunt(positioner.Velocity{X: 2}, 0)
+
+		// assert
+		require.True(t, ok)
+		require.InDelta(t, (100-1)/5.0, result.Duration, 1e-9)
+	})
+
+	t.Run("with the current the hunter gains faster", func(t *testing.T) {
+		// act
+		result, ok := hunt(positioner.Velocity{X: 2}, 1)
+
+		// assert
+		require.True(t, ok)
+		require.InDelta(t, (100-1)/6.0, result.Duration, 1e-9)
+	})
+
+	t.Run("against the current the hunter gains slower", func(t *testing.T) {
+		// act
+		result, ok := hunt(positioner.Velocity{X: -2}, 1)
+
+		// assert
+		require.True(t, ok)
+		require.InDelta(t, (100-1)/4.0, result.Duration, 1e-9)
+	})
+}
@@ -0,0 +1,167 @@
+package simulator
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"testdoubles/internal/positioner"
+)
+
+// TrajectoryRecorder is an interface that represents a recorder of the path of the subjects of a simulation
+// Simulators write into it on each step
+// - the ones that solve the hunt in closed form (default and intercept) have no steps: they record only the start and the end
+type TrajectoryRecorder interface {
+	// Record records the state of the hunter and the prey at the current time of the simulation (see Subject.Time)
+	Record(hunter, prey *Subject)
+}
+
+// Sample is a struct that represents the state of a subject at a moment of a simulation
+type Sample struct {
+	// Time is the time elapsed since the start of the simulation (in seconds)
+	Time float64 `json:"time"`
+	// Position is the position of the subject
+	Position positioner.Position `json:"position"`
+	// Speed is the speed of the subject (in m/s)
+	Speed float64 `json:"speed"`
+	// Heading is the direction the subject is moving to (normalized)
+	Heading positioner.Position `json:"heading"`
+}
+
+// NewTrajectory creates a new empty Trajectory
+func NewTrajectory() (tr *Trajectory) {
+	tr = &Trajectory{
+		Hunter: []Sample{},
+		Prey:   []Sample{},
+	}
+	return
+}
+
+// Trajectory is the path of the hunter and the prey along a simulation
+// It is an implementation of TrajectoryRecorder
+type Trajectory struct {
+	// Hunter is the path of the hunter
+	Hunter []Sample `json:"hunter"`
+	// Prey is the path of the prey
+	Prey []Sample `json:"prey"`
+}
+
+// Record records the state of the hunter and the prey
+func (t *Trajectory) Record(hunter, prey *Subject) {
+	t.Hunter = append(t.Hunter, sampleOf(hunter))
+	t.Prey = append(t.Prey, sampleOf(prey))
+}
+
+// recordEnds records only the start and the end states of the subjects
+// It is used by the simulators that solve the hunt in closed form
+// - into a new trajectory of the result if record is set, and into the recorder if there is one
+func recordEnds(result *CatchResult, record bool, recorder TrajectoryRecorder, hunterStart, preyStart, hunterEnd, preyEnd *Subject) {
+	if record {
+		result.Trajectory = NewTrajectory()
+		result.Trajectory.Record(hunterStart, preyStart)
+		result.Trajectory.Record(hunterEnd, preyEnd)
+	}
+	if recorder != nil {
+		recorder.Record(hunterStart, preyStart)
+		recorder.Record(hunterEnd, preyEnd)
+	}
+}
+
+// sampleOf returns the sample of the current state of a subject
+func sampleOf(s *Subject) (sample Sample) {
+	sample = Sample{Time: s.Time, Speed: s.Speed}
+	if s.Position != nil {
+		sample.Position = *s.Position
+	}
+	if s.Heading != nil {
+		sample.Heading = s.Heading.Normalize()
+	}
+	return
+}
+
+// WriteJSON writes the trajectory in JSON format
+func (t *Trajectory) WriteJSON(w io.Writer) (err error) {
+	err = json.NewEncoder(w).Encode(t)
+	return
+}
+
+// WriteCSV writes the trajectory in CSV format: one row per sample of each subject
+func (t *Trajectory) WriteCSV(w io.Writer) (err error) {
+	cw := csv.NewWriter(w)
+
+	// header
+	err = cw.Write([]string{"subject", "time", "x", "y", "z", "speed", "heading_x", "heading_y", "heading_z"})
+	if err != nil {
+		return
+	}
+
+	// rows
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	for _, path := range []struct {
+		name    string
+		samples []Sample
+	}{{"hunter", t.Hunter}, {"prey", t.Prey}} {
+		for _, s := range path.samples {
+			err = cw.Write([]string{
+				path.name, f(s.Time),
+				f(s.Position.X), f(s.Position.Y), f(s.Position.Z),
+				f(s.Speed),
+				f(s.Heading.X), f(s.Heading.Y), f(s.Heading.Z),
+			})
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	cw.Flush()
+	err = cw.Error()
+	return
+}
+
+// geoJSONFeatureCollection is a GeoJSON-like collection of features
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+// geoJSONFeature is a GeoJSON-like feature with a LineString geometry
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoJSONLineString      `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// geoJSONLineString is a GeoJSON-like LineString: a list of [x, y, z] coordinates
+type geoJSONLineString struct {
+	Type        string       `json:"type"`
+	Coordinates [][3]float64 `json:"coordinates"`
+}
+
+// WriteGeoJSON writes the trajectory as a GeoJSON-like FeatureCollection with one LineString per subject
+// - coordinates are [x, y, z] in meters, times and speeds go in the properties of each feature
+func (t *Trajectory) WriteGeoJSON(w io.Writer) (err error) {
+	fc := geoJSONFeatureCollection{Type: "FeatureCollection", Features: []geoJSONFeature{}}
+	for _, path := range []struct {
+		name    string
+		samples []Sample
+	}{{"hunter", t.Hunter}, {"prey", t.Prey}} {
+		coordinates := make([][3]float64, 0, len(path.samples))
+		times := make([]float64, 0, len(path.samples))
+		speeds := make([]float64, 0, len(path.samples))
+		for _, s := range path.samples {
+			coordinates = append(coordinates, [3]float64{s.Position.X, s.Position.Y, s.Position.Z})
+			times = append(times, s.Time)
+			speeds = append(speeds, s.Speed)
+		}
+
+		fc.Features = append(fc.Features, geoJSONFeature{
+			Type:       "Feature",
+			Geometry:   geoJSONLineString{Type: "LineString", Coordinates: coordinates},
+			Properties: map[string]interface{}{"subject": path.name, "times": times, "speeds": speeds},
+		})
+	}
+
+	err = json.NewEncoder(w).Encode(fc)
+	return
+}
@@ -0,0 +1,263 @@
+package simulator_test
+
+import (
+	"bytes"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Unit Tests for Trajectory
+func TestTrajectory_Record(t *testing.T) {
+	t.Run("stepped simulator records every step", func(t *testing.T) {
+		// arrange
+		impl := simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 100,
+			TimeStep:       1,
+			CaptureRadius:  1,
+			Positioner:     positioner.NewPositionerDefault(),
+			Record:         true,
+		})
+		hunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
+		prey := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 20, Y: 0, Z: 0}}
+
+		// act
+		result, ok := impl.CanCatch(hunter, prey)
+
+		// assert
+		require.True(t, ok)
+		require.NotNil(t, result.Trajectory)
+		require.Equal(t, len(result.Trajectory.Hunter), len(result.Trajectory.Prey))
+		require.Greater(t, len(result.Trajectory.Hunter), 2)
+		first, last := result.Trajectory.Hunter[0], result.Trajectory.Hunter[len(result.Trajectory.Hunter)-1]
+		require.Equal(t, 0.0, first.Time)
+		require.Equal(t, positioner.Position{X: 0, Y: 0, Z: 0}, first.Position)
+		require.InDelta(t, result.Duration, last.Time, 1e-9)
+		require.Equal(t, *result.HunterPosition, last.Position)
+	})
+
+	t.Run("closed form simulator records the start and the end", func(t *testing.T) {
+		// arrange
+		impl := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+			MaxTimeToCatch: 100,
+			Positioner:     positioner.NewPositionerDefault(),
+			Record:         true,
+		})
+		hunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
+		prey := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 100, Y: 0, Z: 0}}
+
+		// act
+		result, ok := impl.CanCatch(hunter, prey)
+
+		// assert
+		expectedHunter := []simulator.Sample{
+			{Time: 0, Position: positioner.Position{X: 0, Y: 0, Z: 0}, Speed: 10, Heading: positioner.Position{X: 1, Y: 0, Z: 0}},
+			{Time: 20, Position: positioner.Position{X: 200, Y: 0, Z: 0}, Speed: 10, Heading: positioner.Position{X: 1, Y: 0, Z: 0}},
+		}
+		require.True(t, ok)
+		require.NotNil(t, result.Trajectory)
+		require.Len(t, result.Trajectory.Prey, 2)
+		require.Equal(t, expectedHunter, result.Trajectory.Hunter)
+	})
+
+	t.Run("stepped simulator records into the given recorder", func(t *testing.T) {
+		// arrange
+		recorder := simulator.NewTrajectory()
+		impl := simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+			MaxTimeToCatch: 100,
+			TimeStep:       1,
+			CaptureRadius:  1,
+			Positioner:     positioner.NewPositionerDefault(),
+			Recorder:       recorder,
+		})
+		hunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
+		prey := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 20, Y: 0, Z: 0}}
+
+		// act
+		result, ok := impl.CanCatch(hunter, prey)
+
+		// assert
+		require.True(t, ok)
+		require.Nil(t, result.Trajectory)
+		require.Greater(t, len(recorder.Hunter), 2)
+		require.Equal(t, len(recorder.Hunter), len(recorder.Prey))
+		require.InDelta(t, result.Duration, recorder.Hunter[len(recorder.Hunter)-1].Time, 1e-9)
+	})
+
+	t.Run("closed form simulator records the start and the end into the given recorder", func(t *testing.T) {
+		// arrange
+		recorder := simulator.NewTrajectory()
+		impl := simulator.NewCatchSimulatorIntercept(&simulator.ConfigCatchSimulatorIntercept{
+			MaxTimeToCatch: 100,
+			Record:         true,
+			Recorder:       recorder,
+		})
+		hunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
+		prey := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 100, Y: 0, Z: 0}}
+
+		// act
+		result, ok := impl.CanCatch(hunter, prey)
+
+		// assert
+		require.True(t, ok)
+		require.NotNil(t, result.Trajectory)
+		require.Equal(t, result.Trajectory, recorder)
+	})
+
+	t.Run("samples recorded by each simulator", func(t *testing.T) {
+		type testCase struct {
+			name    string
+			new     func(recorder simulator.TrajectoryRecorder) simulator.CatchSimulator
+			samples int
+		}
+		cases := []testCase{
+			// case 1: stepped - the start and one sample per step (3 whole ones and the last, shorter one)
+			{
+				name: "stepped",
+				new: func(recorder simulator.TrajectoryRecorder) simulator.CatchSimulator {
+					return simulator.NewCatchSimulatorStepped(&simulator.ConfigCatchSimulatorStepped{
+						MaxTimeToCatch: 100,
+						TimeStep:       1,
+						CaptureRadius:  1,
+						Positioner:     positioner.NewPositionerDefault(),
+						Recorder:       recorder,
+					})
+				},
+				samples: 5,
+			},
+			// case 2: default - closed form, the start and the end
+			{
+				name: "default",
+				new: func(recorder simulator.TrajectoryRecorder) simulator.CatchSimulator {
+					return simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+						MaxTimeToCatch: 100,
+						Positioner:     positioner.NewPositionerDefault(),
+						Recorder:       recorder,
+					})
+				},
+				samples: 2,
+			},
+			// case 3: intercept - closed form, the start and the end
+			{
+				name: "intercept",
+				new: func(recorder simulator.TrajectoryRecorder) simulator.CatchSimulator {
+					return simulator.NewCatchSimulatorIntercept(&simulator.ConfigCatchSimulatorIntercept{
+						MaxTimeToCatch: 100,
+						Recorder:       recorder,
+					})
+				},
+				samples: 2,
+			},
+		}
+
+		// run tests
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				// arrange
+				recorder := simulator.NewTrajectory()
+				impl := c.new(recorder)
+				hunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
+				prey := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 20, Y: 0, Z: 0}}
+
+				// act
+				_, ok := impl.CanCatch(hunter, prey)
+
+				// assert
+				require.True(t, ok)
+				require.Len(t, recorder.Hunter, c.samples)
+				require.Len(t, recorder.Prey, c.samples)
+			})
+		}
+	})
+
+	t.Run("nothing is recorded by default", func(t *testing.T) {
+		// arrange
+		impl := simulator.NewCatchSimulatorDefault(&simulator.ConfigCatchSimulatorDefault{
+			MaxTimeToCatch: 100,
+			Positioner:     positioner.NewPositionerDefault(),
+		})
+		hunter := &simulator.Subject{Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}}
+		prey := &simulator.Subject{Speed: 5, Position: &positioner.Position{X: 100, Y: 0, Z: 0}}
+
+		// act
+		result, _ := impl.CanCatch(hunter, prey)
+
+		// assert
+		require.Nil(t, result.Trajectory)
+	})
+}
+
+func TestTrajectory_Write(t *testing.T) {
+	// trajectory
+	tr := simulator.NewTrajectory()
+	tr.Record(
+		&simulator.Subject{Time: 0, Speed: 10, Position: &positioner.Position{X: 0, Y: 0, Z: 0}, Heading: &positioner.Position{X: 2, Y: 0, Z: 0}},
+		&simulator.Subject{Time: 0, Speed: 5, Position: &positioner.Position{X: 10, Y: 0, Z: 0}},
+	)
+	tr.Record(
+		&simulator.Subject{Time: 1, Speed: 10, Position: &positioner.Position{X: 10, Y: 0, Z: 0}},
+		&simulator.Subject{Time: 1, Speed: 5, Position: &positioner.Position{X: 15, Y: 0, Z: 0}},
+	)
+
+	t.Run("json", func(t *testing.T) {
+		// act
+		var buf bytes.Buffer
+		err := tr.WriteJSON(&buf)
+
+		// assert
+		expected := `{
+			"hunter": [
+				{"time": 0, "position": {"X": 0, "Y": 0, "Z": 0}, "speed": 10, "heading": {"X": 1, "Y": 0, "Z": 0}},
+				{"time": 1, "position": {"X": 10, "Y": 0, "Z": 0}, "speed": 10, "heading": {"X": 0, "Y": 0, "Z": 0}}
+			],
+			"prey": [
+				{"time": 0, "position": {"X": 10, "Y": 0, "Z": 0}, "speed": 5, "heading": {"X": 0, "Y": 0, "Z": 0}},
+				{"time": 1, "position": {"X": 15, "Y": 0, "Z": 0}, "speed": 5, "heading": {"X": 0, "Y": 0, "Z": 0}}
+			]
+		}`
+		require.NoError(t, err)
+		require.JSONEq(t, expected, buf.String())
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		// act
+		var buf bytes.Buffer
+		err := tr.WriteCSV(&buf)
+
+		// assert
+		expected := "subject,time,x,y,z,speed,heading_x,heading_y,heading_z\n" +
+			"hunter,0,0,0,0,10,1,0,0\n" +
+			"hunter,1,10,0,0,10,0,0,0\n" +
+			"prey,0,10,0,0,5,0,0,0\n" +
+			"prey,1,15,0,0,5,0,0,0\n"
+		require.NoError(t, err)
+		require.Equal(t, expected, buf.String())
+	})
+
+	t.Run("geojson", func(t *testing.T) {
+		// act
+		var buf bytes.Buffer
+		err := tr.WriteGeoJSON(&buf)
+
+		// assert
+		expected := `{
+			"type": "FeatureCollection",
+			"features": [
+				{
+					"type": "Feature",
+					"geometry": {"type": "LineString", "coordinates": [[0, 0, 0], [10, 0, 0]]},
+					"properties": {"subject": "hunter", "times": [0, 1], "speeds": [10, 10]}
+				},
+				{
+					"type": "Feature",
+					"geometry": {"type": "LineString", "coordinates": [[10, 0, 0], [15, 0, 0]]},
+					"properties": {"subject": "prey", "times": [0, 1], "speeds": [5, 5]}
+				}
+			]
+		}`
+		require.NoError(t, err)
+		require.JSONEq(t, expected, buf.String())
+	})
+}
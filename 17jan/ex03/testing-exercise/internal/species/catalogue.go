@@ -0,0 +1,176 @@
+package species
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"testdoubles/internal/simulator"
+)
+
+// ErrSpeciesUnknown is returned when a species is not in a catalogue
+var ErrSpeciesUnknown = errors.New("unknown species")
+
+// Names of the species of the default catalogue
+const (
+	// hunters
+	WhiteShark = "white-shark"
+	MakoShark  = "mako-shark"
+	Orca       = "orca"
+	Barracuda  = "barracuda"
+	Dolphin    = "dolphin"
+	// preys
+	Tuna     = "tuna"
+	Seal     = "seal"
+	Sardine  = "sardine"
+	Squid    = "squid"
+	Mackerel = "mackerel"
+)
+
+// Catalogue is a set of profiles of species by name
+type Catalogue map[string]Profile
+
+// Default is the catalogue of the species known by the simulator
+// - the speeds of the white shark and the tuna are the ones CreateWhiteShark and CreateTuna always drew
+var Default = Catalogue{
+	WhiteShark: {
+		Name:          WhiteShark,
+		Speed:         Distribution{Min: 15, Max: 159},
+		Acceleration:  4,
+		Cruise:        0.3,
+		Stamina:       40,
+		Size:          4.5,
+		CaptureRadius: 1.5,
+		Depth:         simulator.Range{Min: 0, Max: 1200},
+	},
+	MakoShark: {
+		Name:          MakoShark,
+		Speed:         Distribution{Min: 10, Max: 20, Mean: 15, StdDev: 3},
+		Acceleration:  6,
+		Cruise:        0.3,
+		Stamina:       30,
+		Size:          3.2,
+		CaptureRadius: 1.2,
+		Depth:         simulator.Range{Min: 0, Max: 500},
+	},
+	Orca: {
+		Name:          Orca,
+		Speed:         Distribution{Min: 8, Max: 15, Mean: 12, StdDev: 2},
+		Acceleration:  3,
+		Cruise:        0.4,
+		Stamina:       60,
+		Size:          7,
+		CaptureRadius: 2.5,
+		Depth:         simulator.Range{Min: 0, Max: 300},
+	},
+	Barracuda: {
+		Name:          Barracuda,
+		Speed:         Distribution{Min: 8, Max: 12, Mean: 10, StdDev: 1.5},
+		Acceleration:  8,
+		Cruise:        0.2,
+		Stamina:       10,
+		Size:          1.5,
+		CaptureRadius: 0.5,
+		Depth:         simulator.Range{Min: 0, Max: 100},
+	},
+	Dolphin: {
+		Name:          Dolphin,
+		Speed:         Distribution{Min: 6, Max: 11, Mean: 9, StdDev: 1.5},
+		Acceleration:  4,
+		Cruise:        0.4,
+		Stamina:       120,
+		Size:          2.5,
+		CaptureRadius: 0.8,
+		Depth:         simulator.Range{Min: 0, Max: 300},
+	},
+	Tuna: {
+		Name:         Tuna,
+		Speed:        Distribution{Min: 15, Max: 267},
+		Acceleration: 10,
+		Cruise:       0.3,
+		Stamina:      60,
+		Size:         2,
+		Depth:        simulator.Range{Min: 0, Max: 500},
+	},
+	Seal: {
+		Name:         Seal,
+		Speed:        Distribution{Min: 5, Max: 10, Mean: 7, StdDev: 1},
+		Acceleration: 3,
+		Cruise:       0.3,
+		Stamina:      90,
+		Size:         1.8,
+		Depth:        simulator.Range{Min: 0, Max: 300},
+	},
+	Sardine: {
+		Name:         Sardine,
+		Speed:        Distribution{Min: 1.2, Max: 3, Mean: 2, StdDev: 0.3},
+		Acceleration: 10,
+		Cruise:       0.4,
+		Stamina:      20,
+		Size:         0.2,
+		Depth:        simulator.Range{Min: 0, Max: 50},
+	},
+	Squid: {
+		Name:         Squid,
+		Speed:        Distribution{Min: 2, Max: 11, Mean: 5, StdDev: 1},
+		Acceleration: 15,
+		Cruise:       0.1,
+		Stamina:      3,
+		Size:         0.6,
+		Depth:        simulator.Range{Min: 0, Max: 800},
+	},
+	Mackerel: {
+		Name:         Mackerel,
+		Speed:        Distribution{Min: 3, Max: 8, Mean: 5, StdDev: 1},
+		Acceleration: 8,
+		Cruise:       0.3,
+		Stamina:      30,
+		Size:         0.4,
+		Depth:        simulator.Range{Min: 0, Max: 200},
+	},
+}
+
+// Lookup returns the profile of a species
+func (c Catalogue) Lookup(name string) (p Profile, err error) {
+	p, ok := c[name]
+	if !ok {
+		err = fmt.Errorf("%w: %s", ErrSpeciesUnknown, name)
+		return
+	}
+	return
+}
+
+// Names returns the names of the species of the catalogue, sorted
+func (c Catalogue) Names() (names []string) {
+	names = make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+// LoadCatalogue reads a catalogue from a JSON object of profiles by name
+// - the name of a profile is the key it has in the object
+func LoadCatalogue(r io.Reader) (c Catalogue, err error) {
+	var profiles map[string]Profile
+	if err = json.NewDecoder(r).Decode(&profiles); err != nil {
+		err = fmt.Errorf("%w: %v", ErrProfileInvalid, err)
+		return
+	}
+
+	c = make(Catalogue, len(profiles))
+	for name, p := range profiles {
+		p.Name = name
+		c[name] = p
+	}
+	// - in order, so the same file always fails on the same profile
+	for _, name := range c.Names() {
+		if err = c[name].Validate(); err != nil {
+			c = nil
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,87 @@
+package species_test
+
+import (
+	"strings"
+	"testdoubles/internal/species"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for Catalogue
+func TestCatalogue_Default(t *testing.T) {
+	// act & assert
+	require.GreaterOrEqual(t, len(species.Default), 10)
+	for name, p := range species.Default {
+		require.Equal(t, name, p.Name)
+		require.NoError(t, p.Validate(), name)
+	}
+}
+
+func TestCatalogue_Lookup(t *testing.T) {
+	t.Run("known species", func(t *testing.T) {
+		// act
+		p, err := species.Default.Lookup(species.Orca)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, species.Orca, p.Name)
+	})
+
+	t.Run("unknown species", func(t *testing.T) {
+		// act
+		_, err := species.Default.Lookup("kraken")
+
+		// assert
+		require.ErrorIs(t, err, species.ErrSpeciesUnknown)
+		require.EqualError(t, err, "unknown species: kraken")
+	})
+}
+
+func TestCatalogue_Names(t *testing.T) {
+	// arrange
+	c := species.Catalogue{"b": {}, "c": {}, "a": {}}
+
+	// act & assert
+	require.Equal(t, []string{"a", "b", "c"}, c.Names())
+}
+
+func TestLoadCatalogue(t *testing.T) {
+	t.Run("profiles by name", func(t *testing.T) {
+		// arrange
+		r := strings.NewReader(`{
+			"grouper": {"speed": {"min": 1, "max": 4}, "size": 1.2, "capture_radius": 0.6, "depth": {"min": 5, "max": 100}},
+			"marlin": {"speed": {"min": 20, "max": 36, "mean": 30, "std_dev": 3}, "acceleration": 12, "cruise": 0.2, "stamina": 25}
+		}`)
+
+		// act
+		c, err := species.LoadCatalogue(r)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, []string{"grouper", "marlin"}, c.Names())
+		require.Equal(t, "marlin", c["marlin"].Name)
+		require.Equal(t, species.Distribution{Min: 20, Max: 36, Mean: 30, StdDev: 3}, c["marlin"].Speed)
+		require.Equal(t, 0.6, c["grouper"].CaptureRadius)
+		require.Equal(t, 100.0, c["grouper"].Depth.Max)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		// act
+		c, err := species.LoadCatalogue(strings.NewReader(`{"grouper": [`))
+
+		// assert
+		require.ErrorIs(t, err, species.ErrProfileInvalid)
+		require.Nil(t, c)
+	})
+
+	t.Run("invalid profile", func(t *testing.T) {
+		// act
+		c, err := species.LoadCatalogue(strings.NewReader(`{"grouper": {"speed": {"min": 1, "max": 4}}, "ghost": {"speed": {"min": 0, "max": 0}}}`))
+
+		// assert
+		require.ErrorIs(t, err, species.ErrProfileInvalid)
+		require.EqualError(t, err, "invalid species profile: ghost: speed must be a range of positive values")
+		require.Nil(t, c)
+	})
+}
@@ -0,0 +1,113 @@
+package species
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+)
+
+// ErrProfileInvalid is returned when a profile of a species is not valid
+var ErrProfileInvalid = errors.New("invalid species profile")
+
+// Distribution is how a value is spread among the individuals of a species
+// - with a standard deviation, it is normal around the mean and clamped to [Min, Max]
+// - without it, it is uniform in [Min, Max]
+type Distribution struct {
+	Min    float64 `json:"min" yaml:"min"`
+	Max    float64 `json:"max" yaml:"max"`
+	Mean   float64 `json:"mean" yaml:"mean"`
+	StdDev float64 `json:"std_dev" yaml:"std_dev"`
+}
+
+// Sample draws a value of the distribution
+// - if rd is nil, the global source of math/rand is used
+func (d Distribution) Sample(rd *rand.Rand) (v float64) {
+	random, normal := rand.Float64, rand.NormFloat64
+	if rd != nil {
+		random, normal = rd.Float64, rd.NormFloat64
+	}
+
+	if d.StdDev <= 0 {
+		v = random()*(d.Max-d.Min) + d.Min
+		return
+	}
+	v = math.Min(d.Max, math.Max(d.Min, d.Mean+normal()*d.StdDev))
+	return
+}
+
+// Profile is what the individuals of a species are like
+// The generic hunters and preys are built from a profile, so a new species is a new profile
+type Profile struct {
+	// Name of the species
+	Name string `json:"name" yaml:"name"`
+	// Speed is the top speed of the individuals (in m/s)
+	Speed Distribution `json:"speed" yaml:"speed"`
+	// Acceleration of the individuals (in m/s², zero to reach their speed instantly)
+	Acceleration float64 `json:"acceleration" yaml:"acceleration"`
+	// Cruise is the fraction of its top speed an individual can hold forever (zero to never get tired)
+	Cruise float64 `json:"cruise" yaml:"cruise"`
+	// Stamina is how long an individual can sprint above its cruise speed (in seconds)
+	Stamina float64 `json:"stamina" yaml:"stamina"`
+	// Size is the length of an individual (in meters)
+	Size float64 `json:"size" yaml:"size"`
+	// CaptureRadius is how close a hunter of the species gets to a prey to catch it (in meters, zero for the default of the world or the simulator)
+	CaptureRadius float64 `json:"capture_radius" yaml:"capture_radius"`
+	// Depth is the range of depths the species prefers (in meters, down from the surface)
+	Depth simulator.Range `json:"depth" yaml:"depth"`
+}
+
+// Validate returns an error if the profile is not valid
+func (p Profile) Validate() (err error) {
+	switch {
+	case p.Name == "":
+		err = fmt.Errorf("%w: name is required", ErrProfileInvalid)
+	case p.Speed.Min < 0 || p.Speed.Max <= 0 || p.Speed.Min > p.Speed.Max:
+		err = fmt.Errorf("%w: %s: speed must be a range of positive values", ErrProfileInvalid, p.Name)
+	case p.Speed.StdDev < 0:
+		err = fmt.Errorf("%w: %s: standard deviation of the speed can not be negative", ErrProfileInvalid, p.Name)
+	case p.Acceleration < 0 || p.Stamina < 0 || p.Size < 0 || p.CaptureRadius < 0:
+		err = fmt.Errorf("%w: %s: acceleration, stamina, size and capture radius can not be negative", ErrProfileInvalid, p.Name)
+	case p.Cruise < 0 || p.Cruise > 1:
+		err = fmt.Errorf("%w: %s: cruise must be a fraction of the speed between 0 and 1", ErrProfileInvalid, p.Name)
+	case p.Depth.Min < 0 || p.Depth.Min > p.Depth.Max:
+		err = fmt.Errorf("%w: %s: depth must be a range of values from the surface down", ErrProfileInvalid, p.Name)
+	}
+	return
+}
+
+// Kinematics returns how the speed of an individual with a top speed changes along a hunt
+func (p Profile) Kinematics(speed float64) (kinematics simulator.Kinematics) {
+	kinematics = simulator.Kinematics{
+		Acceleration: p.Acceleration,
+		CruiseSpeed:  p.Cruise * speed,
+		Stamina:      p.Stamina,
+		MaxStamina:   p.Stamina,
+	}
+	return
+}
+
+// Spawn returns a random position of an individual in the arena, at the depth the species prefers
+// - the surface is the top of the arena: the depths below the seabed are at the seabed
+// - if arena is nil, the default arena is used
+// - if rd is nil, the global source of math/rand is used
+func (p Profile) Spawn(arena *positioner.Arena, rd *rand.Rand) (position *positioner.Position) {
+	if arena == nil {
+		arena = positioner.NewArenaDefault()
+	}
+	random := rand.Float64
+	if rd != nil {
+		random = rd.Float64
+	}
+
+	min, max := arena.Min(), arena.Max()
+	position = &positioner.Position{
+		X: random()*(max.X-min.X) + min.X,
+		Y: random()*(max.Y-min.Y) + min.Y,
+	}
+	depth := random()*(p.Depth.Max-p.Depth.Min) + p.Depth.Min
+	position.Z = max.Z - math.Min(depth, max.Z-min.Z)
+	return
+}
@@ -0,0 +1,159 @@
+package species_test
+
+import (
+	"math/rand"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+	"testdoubles/internal/species"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for Distribution
+func TestDistribution_Sample(t *testing.T) {
+	t.Run("uniform - in range", func(t *testing.T) {
+		// arrange
+		d := species.Distribution{Min: 15, Max: 159}
+		rd := rand.New(rand.NewSource(1))
+
+		// act & assert
+		for i := 0; i < 1000; i++ {
+			v := d.Sample(rd)
+			require.GreaterOrEqual(t, v, 15.0)
+			require.Less(t, v, 159.0)
+		}
+	})
+
+	t.Run("uniform - the same draws as scaling the source", func(t *testing.T) {
+		// arrange
+		d := species.Distribution{Min: 15, Max: 159}
+
+		// act
+		v := d.Sample(rand.New(rand.NewSource(42)))
+
+		// assert
+		require.Equal(t, rand.New(rand.NewSource(42)).Float64()*144.0+15.0, v)
+	})
+
+	t.Run("normal - around the mean and clamped", func(t *testing.T) {
+		// arrange
+		d := species.Distribution{Min: 10, Max: 14, Mean: 12, StdDev: 2}
+		rd := rand.New(rand.NewSource(1))
+
+		// act
+		var sum float64
+		for i := 0; i < 10000; i++ {
+			v := d.Sample(rd)
+			require.GreaterOrEqual(t, v, 10.0)
+			require.LessOrEqual(t, v, 14.0)
+			sum += v
+		}
+
+		// assert
+		require.InDelta(t, 12.0, sum/10000, 0.05)
+	})
+
+	t.Run("same seed, same value", func(t *testing.T) {
+		// arrange
+		d := species.Distribution{Min: 0, Max: 100, Mean: 50, StdDev: 20}
+
+		// act & assert
+		require.Equal(t, d.Sample(rand.New(rand.NewSource(7))), d.Sample(rand.New(rand.NewSource(7))))
+	})
+}
+
+// Tests for Profile
+func TestProfile_Validate(t *testing.T) {
+	valid := species.Profile{
+		Name:  "valid",
+		Speed: species.Distribution{Min: 1, Max: 2},
+		Depth: simulator.Range{Min: 0, Max: 10},
+	}
+	type testCase struct {
+		name    string
+		profile func(p *species.Profile)
+		ok      bool
+	}
+
+	cases := []testCase{
+		// case 1: valid profile
+		{name: "valid", profile: func(p *species.Profile) {}, ok: true},
+		// case 2: no name
+		{name: "no name", profile: func(p *species.Profile) { p.Name = "" }},
+		// case 3: no top speed
+		{name: "no top speed", profile: func(p *species.Profile) { p.Speed.Max = 0 }},
+		// case 4: speed range upside down
+		{name: "speed upside down", profile: func(p *species.Profile) { p.Speed.Min = 3 }},
+		// case 5: negative standard deviation
+		{name: "negative standard deviation", profile: func(p *species.Profile) { p.Speed.StdDev = -1 }},
+		// case 6: negative stamina
+		{name: "negative stamina", profile: func(p *species.Profile) { p.Stamina = -1 }},
+		// case 7: cruise above the top speed
+		{name: "cruise above 1", profile: func(p *species.Profile) { p.Cruise = 1.5 }},
+		// case 8: depth above the surface
+		{name: "depth above the surface", profile: func(p *species.Profile) { p.Depth.Min = -5 }},
+	}
+
+	// run tests
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// arrange
+			p := valid
+			c.profile(&p)
+
+			// act
+			err := p.Validate()
+
+			// assert
+			if c.ok {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, species.ErrProfileInvalid)
+		})
+	}
+}
+
+func TestProfile_Spawn(t *testing.T) {
+	t.Run("at the depth of the species", func(t *testing.T) {
+		// arrange
+		p := species.Profile{Depth: simulator.Range{Min: 10, Max: 50}}
+		arena := positioner.NewArena(positioner.ConfigArena{
+			X: positioner.Bounds{Min: -100, Max: 100},
+			Y: positioner.Bounds{Min: 0, Max: 200},
+			Z: positioner.Bounds{Min: -300, Max: 0},
+		})
+		rd := rand.New(rand.NewSource(1))
+
+		// act & assert
+		for i := 0; i < 100; i++ {
+			position := p.Spawn(arena, rd)
+			require.NoError(t, arena.Validate(position))
+			require.GreaterOrEqual(t, position.Z, -50.0)
+			require.LessOrEqual(t, position.Z, -10.0)
+		}
+	})
+
+	t.Run("deeper than the arena - at the seabed", func(t *testing.T) {
+		// arrange
+		p := species.Profile{Depth: simulator.Range{Min: 800, Max: 1000}}
+
+		// act
+		position := p.Spawn(nil, rand.New(rand.NewSource(1)))
+
+		// assert
+		require.Equal(t, 0.0, position.Z)
+	})
+}
+
+func TestProfile_Kinematics(t *testing.T) {
+	// arrange
+	p := species.Profile{Acceleration: 3, Cruise: 0.25, Stamina: 40}
+
+	// act
+	kinematics := p.Kinematics(12)
+
+	// assert
+	require.Equal(t, simulator.Kinematics{Acceleration: 3, CruiseSpeed: 3, Stamina: 40, MaxStamina: 40}, kinematics)
+}
@@ -0,0 +1,33 @@
+package world
+
+import "testdoubles/internal/positioner"
+
+// TargetSelector is an interface that represents how a hunter picks the prey it chases
+// It is asked for the target of each hunter on each step of the simulation of a world
+type TargetSelector interface {
+	// Select returns the prey the hunter chases (nil if there is no prey)
+	// - hunter: is the current state of the hunter
+	// - preys: are the current states of the preys left in the world
+	Select(hunter *Member, preys []*Member) (target *Member)
+}
+
+// IndexedTargetSelector is an interface that represents a TargetSelector that finds the target with a spatial index
+// The world asks it instead of Select when the world has an index of its preys
+type IndexedTargetSelector interface {
+	// SelectIndexed returns the prey the hunter chases (nil if there is no prey)
+	// - index: has the positions of the preys left in the world by their ID
+	// - preys: are the current states of the preys left in the world by their ID
+	SelectIndexed(hunter *Member, index positioner.SpatialIndex, preys map[string]*Member) (target *Member)
+}
+
+// selectBy returns the prey with the lowest score, the nearest to the hunter on a tie (nil if there is no prey)
+func selectBy(hunter *Member, preys []*Member, score func(prey *Member) float64) (target *Member) {
+	var bestScore, bestDistance float64
+	for _, p := range preys {
+		s, d := score(p), distance(hunter, p)
+		if target == nil || s < bestScore || (s == bestScore && d < bestDistance) {
+			target, bestScore, bestDistance = p, s, d
+		}
+	}
+	return
+}
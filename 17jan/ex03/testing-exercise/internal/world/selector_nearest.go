@@ -0,0 +1,28 @@
+package world
+
+import "testdoubles/internal/positioner"
+
+// NewTargetSelectorNearest creates a new TargetSelectorNearest
+func NewTargetSelectorNearest() (ts *TargetSelectorNearest) {
+	ts = &TargetSelectorNearest{}
+	return
+}
+
+// TargetSelectorNearest is an implementation of TargetSelector (and IndexedTargetSelector)
+// The hunter chases the nearest prey
+// - with an index, the nearest prey on a tie is the one with the lowest ID
+type TargetSelectorNearest struct{}
+
+// Select returns the nearest prey to the hunter
+func (s *TargetSelectorNearest) Select(hunter *Member, preys []*Member) (target *Member) {
+	target = selectBy(hunter, preys, func(prey *Member) float64 { return 0 })
+	return
+}
+
+// SelectIndexed returns the nearest prey to the hunter, found with the index of the preys
+func (s *TargetSelectorNearest) SelectIndexed(hunter *Member, index positioner.SpatialIndex, preys map[string]*Member) (target *Member) {
+	if ids := index.Nearest(*hunter.Subject.Position, 1); len(ids) > 0 {
+		target = preys[ids[0]]
+	}
+	return
+}
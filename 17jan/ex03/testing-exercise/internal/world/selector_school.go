@@ -0,0 +1,29 @@
+package world
+
+// NewTargetSelectorSchool creates a new TargetSelectorSchool
+func NewTargetSelectorSchool(school string) (ts *TargetSelectorSchool) {
+	ts = &TargetSelectorSchool{school: school}
+	return
+}
+
+// TargetSelectorSchool is an implementation of TargetSelector
+// The hunter chases the nearest member of a school, and the nearest prey once the school is gone
+type TargetSelectorSchool struct {
+	// school is the ID of the school the hunter targets
+	school string
+}
+
+// Select returns the nearest member of the school
+func (s *TargetSelectorSchool) Select(hunter *Member, preys []*Member) (target *Member) {
+	var members []*Member
+	for _, p := range preys {
+		if p.School == s.school {
+			members = append(members, p)
+		}
+	}
+	if len(members) == 0 {
+		members = preys
+	}
+	target = nearest(hunter, members)
+	return
+}
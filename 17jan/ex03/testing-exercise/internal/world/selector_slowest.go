@@ -0,0 +1,17 @@
+package world
+
+// NewTargetSelectorSlowest creates a new TargetSelectorSlowest
+func NewTargetSelectorSlowest() (ts *TargetSelectorSlowest) {
+	ts = &TargetSelectorSlowest{}
+	return
+}
+
+// TargetSelectorSlowest is an implementation of TargetSelector
+// The hunter chases the prey with the lowest top speed, the nearest one on a tie
+type TargetSelectorSlowest struct{}
+
+// Select returns the slowest prey
+func (s *TargetSelectorSlowest) Select(hunter *Member, preys []*Member) (target *Member) {
+	target = selectBy(hunter, preys, func(prey *Member) float64 { return prey.Subject.TopSpeed() })
+	return
+}
@@ -0,0 +1,57 @@
+package world_test
+
+import (
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/simulator"
+	"testdoubles/internal/world"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Unit Tests for the implementations of TargetSelector
+func TestTargetSelector_Select(t *testing.T) {
+	hunter := &world.Member{ID: "shark", Subject: simulator.Subject{Position: &positioner.Position{}}}
+	// - near: close, fast and fresh
+	near := &world.Member{ID: "near", Subject: simulator.Subject{
+		Position:   &positioner.Position{X: 10},
+		Speed:      20,
+		Kinematics: simulator.Kinematics{CruiseSpeed: 10, Stamina: 10, MaxStamina: 10},
+	}}
+	// - slow: far and slow, never gets tired
+	slow := &world.Member{ID: "slow", Subject: simulator.Subject{
+		Position: &positioner.Position{X: 50},
+		Speed:    5,
+	}}
+	// - tired: far and fast, almost out of stamina
+	tired := &world.Member{ID: "tired", Subject: simulator.Subject{
+		Position:   &positioner.Position{X: 100},
+		Speed:      20,
+		Kinematics: simulator.Kinematics{CruiseSpeed: 10, Stamina: 1, MaxStamina: 10},
+	}}
+	preys := []*world.Member{tired, slow, near}
+
+	type testCase struct {
+		name     string
+		selector world.TargetSelector
+		preys    []*world.Member
+		expected *world.Member
+	}
+	cases := []testCase{
+		{name: "nearest", selector: world.NewTargetSelectorNearest(), preys: preys, expected: near},
+		{name: "slowest", selector: world.NewTargetSelectorSlowest(), preys: preys, expected: slow},
+		{name: "weakest", selector: world.NewTargetSelectorWeakest(), preys: preys, expected: tired},
+		{name: "weakest - tie goes to the nearest", selector: world.NewTargetSelectorWeakest(), preys: []*world.Member{slow, near}, expected: near},
+		{name: "no preys", selector: world.NewTargetSelectorNearest(), preys: nil, expected: nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// act
+			target := c.selector.Select(hunter, c.preys)
+
+			// assert
+			require.Equal(t, c.expected, target)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package world
+
+// NewTargetSelectorWeakest creates a new TargetSelectorWeakest
+func NewTargetSelectorWeakest() (ts *TargetSelectorWeakest) {
+	ts = &TargetSelectorWeakest{}
+	return
+}
+
+// TargetSelectorWeakest is an implementation of TargetSelector
+// The hunter chases the prey with the least stamina left, the nearest one on a tie
+// - the stamina is compared as the ratio left
+// - preys that never get tired have it full, preys with a cruise speed but no stamina have none
+type TargetSelectorWeakest struct{}
+
+// Select returns the prey with the least stamina left
+func (s *TargetSelectorWeakest) Select(hunter *Member, preys []*Member) (target *Member) {
+	target = selectBy(hunter, preys, func(prey *Member) float64 {
+		if prey.Subject.CruiseSpeed <= 0 {
+			return 1
+		}
+		if prey.Subject.MaxStamina <= 0 {
+			return 0
+		}
+		return prey.Subject.Stamina / prey.Subject.MaxStamina
+	})
+	return
+}
@@ -0,0 +1,472 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testdoubles/internal/hunter"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/prey"
+	"testdoubles/internal/simulator"
+)
+
+const (
+	// defaultTimeStep is the default time step of the simulation in seconds
+	defaultTimeStep = 0.1
+	// defaultCaptureRadius is the default distance at which a hunter catches a prey in meters
+	defaultCaptureRadius = 1.0
+)
+
+var (
+	// ErrMemberIDRequired is returned when a member is added without ID
+	ErrMemberIDRequired = errors.New("member id is required")
+	// ErrMemberIDDuplicated is returned when a member is added with the ID of another member
+	ErrMemberIDDuplicated = errors.New("member id already exists")
+	// ErrMemberPositionRequired is returned when a member is added without position
+	ErrMemberPositionRequired = errors.New("member position is required")
+)
+
+// Member is a hunter or a prey of the world
+type Member struct {
+	// ID identifies the member in the world
+	ID string
+	// Subject is the current state of the member
+	Subject simulator.Subject
+	// School is the ID of the school the member belongs to (only for preys of a school)
+	School string
+	// CaptureRadius is the distance at which the member catches a prey in meters (only for hunters, zero for the one of the world)
+	CaptureRadius float64
+}
+
+// ConfigWorld is the configuration for World
+type ConfigWorld struct {
+	// MaxTime is the max time of the simulation in seconds
+	MaxTime float64
+	// TimeStep is the time step of the simulation in seconds (default: 0.1)
+	TimeStep float64
+	// CaptureRadius is the distance at which a hunter catches a prey in meters (default: 1)
+	CaptureRadius float64
+	// Selector is how each hunter picks its target (default: the nearest prey)
+	Selector TargetSelector
+	// Arena keeps the members inside its bounds (nil to have no bounds)
+	Arena *positioner.Arena
+	// Index keeps the positions of the preys, so the selectors that can use it do not scan all of them (nil to scan them)
+	// - it must be empty: the world fills it
+	Index positioner.SpatialIndex
+}
+
+// NewWorld creates a new empty World
+func NewWorld(cfg *ConfigWorld) (w *World) {
+	// default config
+	timeStep := defaultTimeStep
+	if cfg.TimeStep > 0 {
+		timeStep = cfg.TimeStep
+	}
+	captureRadius := defaultCaptureRadius
+	if cfg.CaptureRadius > 0 {
+		captureRadius = cfg.CaptureRadius
+	}
+	var selector TargetSelector = NewTargetSelectorNearest()
+	if cfg.Selector != nil {
+		selector = cfg.Selector
+	}
+
+	w = &World{
+		maxTime:       cfg.MaxTime,
+		timeStep:      timeStep,
+		captureRadius: captureRadius,
+		selector:      selector,
+		arena:         cfg.Arena,
+		index:         cfg.Index,
+		ids:           make(map[string]bool),
+		schools:       make(map[string]*prey.School),
+		tunas:         make(map[string]*prey.Tuna),
+	}
+	return
+}
+
+// World is a set of hunters and preys that hunt at the same time
+// - each hunter chases the target its selector picks, which may change on each step
+// - each prey runs from the nearest hunter (or evades as its navigator says)
+// - members of a school move together, following the rules of the school
+// - caught preys are removed from the world (and from their school)
+type World struct {
+	// hunters and preys of the world, in the order they were added
+	hunters []*Member
+	preys   []*Member
+	// ids of all the members and schools
+	ids map[string]bool
+	// schools of the world by ID, and the tunas of their members by member ID
+	schools map[string]*prey.School
+	tunas   map[string]*prey.Tuna
+	// elapsed time of the world in seconds
+	elapsed float64
+	// max time of the simulation in seconds
+	maxTime float64
+	// time step of the simulation in seconds
+	timeStep float64
+	// distance at which a hunter catches a prey in meters
+	captureRadius float64
+	// selector of the target of each hunter
+	selector TargetSelector
+	// arena where the members move
+	arena *positioner.Arena
+	// index of the positions of the preys (nil if the world has none)
+	index positioner.SpatialIndex
+}
+
+// AddHunter adds a hunter to the world
+// - the hunter steers as its strategy says, gets tired and catches from its own distance, if it has them
+func (w *World) AddHunter(id string, ht hunter.Hunter) (err error) {
+	m, err := w.member(id, ht.GetSpeed(), ht.GetPosition(), ht)
+	if err != nil {
+		return
+	}
+	if rc, ok := ht.(hunter.Reacher); ok {
+		m.CaptureRadius = rc.GetCaptureRadius()
+	}
+	w.hunters = append(w.hunters, m)
+	w.ids[id] = true
+	return
+}
+
+// AddPrey adds a prey to the world
+// - the prey evades as its evader says and gets tired, if it has them
+func (w *World) AddPrey(id string, pr prey.Prey) (err error) {
+	m, err := w.member(id, pr.GetSpeed(), pr.GetPosition(), pr)
+	if err != nil {
+		return
+	}
+	w.preys = append(w.preys, m)
+	w.ids[id] = true
+	w.indexPrey(m)
+	return
+}
+
+// AddSchool adds every member of a school to the world as a prey
+// - the members are identified as "<id>-1", "<id>-2", ... in the order of the school
+// - they steer following the rules of the school, unless their own evader says otherwise
+func (w *World) AddSchool(id string, s *prey.School) (err error) {
+	if id == "" {
+		err = ErrMemberIDRequired
+		return
+	}
+	if w.ids[id] {
+		err = ErrMemberIDDuplicated
+		return
+	}
+
+	tunas := s.Members()
+	members := make([]*Member, 0, len(tunas))
+	for i, t := range tunas {
+		var m *Member
+		m, err = w.member(fmt.Sprintf("%s-%d", id, i+1), t.GetSpeed(), t.GetPosition(), t)
+		if err != nil {
+			return
+		}
+		m.School = id
+		members = append(members, m)
+	}
+
+	w.ids[id] = true
+	w.schools[id] = s
+	for i, m := range members {
+		w.preys = append(w.preys, m)
+		w.ids[m.ID] = true
+		w.tunas[m.ID] = tunas[i]
+		w.indexPrey(m)
+	}
+	return
+}
+
+// member creates a new member of the world
+// - it takes the navigator and the kinematics of the subject, if it has them
+func (w *World) member(id string, speed float64, position *positioner.Position, subject any) (m *Member, err error) {
+	if id == "" {
+		err = ErrMemberIDRequired
+		return
+	}
+	if w.ids[id] {
+		err = ErrMemberIDDuplicated
+		return
+	}
+	if position == nil {
+		err = ErrMemberPositionRequired
+		return
+	}
+
+	p := *position
+	m = &Member{ID: id, Subject: simulator.Subject{Position: &p, Speed: speed, Time: w.elapsed}}
+	if nv, ok := subject.(simulator.Navigator); ok {
+		m.Subject.Navigator = nv
+	}
+	if sp, ok := subject.(interface{ GetKinematics() simulator.Kinematics }); ok {
+		m.Subject.Kinematics = sp.GetKinematics()
+	}
+	return
+}
+
+// Hunters returns a copy of the hunters of the world
+func (w *World) Hunters() (hunters []Member) {
+	hunters = copyMembers(w.hunters)
+	return
+}
+
+// Preys returns a copy of the preys left in the world
+func (w *World) Preys() (preys []Member) {
+	preys = copyMembers(w.preys)
+	return
+}
+
+// Catch is a prey caught by a hunter
+type Catch struct {
+	// PreyID and HunterID identify the prey and the hunter that caught it
+	PreyID   string
+	HunterID string
+	// Time is the time of the catch in seconds
+	Time float64
+	// Position is where the prey was caught
+	Position positioner.Position
+}
+
+// Result is the result of a simulation of the world
+type Result struct {
+	// Duration is the time the simulation lasted in seconds
+	Duration float64
+	// Catches are the preys caught, in order
+	Catches []Catch
+	// Escaped are the IDs of the preys left when the time ran out
+	Escaped []string
+}
+
+// AllCaught returns true if every prey was caught
+func (r *Result) AllCaught() (ok bool) {
+	ok = len(r.Escaped) == 0
+	return
+}
+
+// Run runs the simulation until all the preys are caught or the time runs out
+func (w *World) Run() (result *Result) {
+	result = &Result{Catches: []Catch{}, Escaped: []string{}}
+	start := w.elapsed
+	for len(w.preys) > 0 && w.elapsed-start < w.maxTime {
+		dt := math.Min(w.timeStep, w.maxTime-(w.elapsed-start))
+		result.Catches = append(result.Catches, w.Step(dt)...)
+	}
+	result.Duration = w.elapsed - start
+	for _, p := range w.preys {
+		result.Escaped = append(result.Escaped, p.ID)
+	}
+	// - the hunt ends with the last catch, not at the end of its step
+	if result.AllCaught() && len(result.Catches) > 0 {
+		result.Duration = result.Catches[len(result.Catches)-1].Time - start
+	}
+	return
+}
+
+// Step moves the world dt seconds and returns the preys caught along the way
+func (w *World) Step(dt float64) (catches []Catch) {
+	for _, m := range w.members() {
+		m.Subject.Time = w.elapsed
+	}
+
+	// every member steers looking at the others as they were at the start of the step
+	hunterHeadings := make([]positioner.Position, len(w.hunters))
+	selectTarget := w.targets()
+	for i, h := range w.hunters {
+		self := h.Subject
+		target := selectTarget(h)
+		if target == nil {
+			continue
+		}
+		other := target.Subject
+		hunterHeadings[i] = simulator.Steer(&self, &other, other.Position.Sub(*self.Position))
+	}
+	preyHeadings := make([]positioner.Position, len(w.preys))
+	schoolHeadings := w.schoolHeadings()
+	for i, p := range w.preys {
+		self := p.Subject
+		// - away from the nearest hunter, or as the school says
+		var fallback positioner.Position
+		threat := nearest(p, w.hunters)
+		if threat != nil {
+			fallback = self.Position.Sub(*threat.Subject.Position)
+		}
+		if heading, ok := schoolHeadings[p.ID]; ok {
+			fallback = heading
+		}
+		if threat == nil {
+			preyHeadings[i] = fallback
+			continue
+		}
+		other := threat.Subject
+		preyHeadings[i] = simulator.Steer(&self, &other, fallback)
+	}
+
+	// every member speeds up or slows down
+	hunterVelocities := make([]positioner.Position, len(w.hunters))
+	for i, h := range w.hunters {
+		h.Subject.Throttle(dt)
+		hunterVelocities[i] = hunterHeadings[i].Normalize().Scale(h.Subject.Speed)
+	}
+	preyVelocities := make([]positioner.Position, len(w.preys))
+	for i, p := range w.preys {
+		p.Subject.Throttle(dt)
+		preyVelocities[i] = preyHeadings[i].Normalize().Scale(p.Subject.Speed)
+	}
+
+	// each prey is caught by the first hunter that gets close enough along the step
+	left := w.preys[:0]
+	leftVelocities := preyVelocities[:0]
+	for i, p := range w.preys {
+		caught, by, at := false, 0, dt
+		for j, h := range w.hunters {
+			radius := w.captureRadius
+			if h.CaptureRadius > 0 {
+				radius = h.CaptureRadius
+			}
+			r := p.Subject.Position.Sub(*h.Subject.Position)
+			v := preyVelocities[i].Sub(hunterVelocities[j])
+			if t, ok := simulator.FirstContact(r, v, radius, dt); ok && (!caught || t < at) {
+				caught, by, at = true, j, t
+			}
+		}
+		if !caught {
+			left = append(left, p)
+			leftVelocities = append(leftVelocities, preyVelocities[i])
+			continue
+		}
+		if p.School != "" {
+			w.schools[p.School].Remove(w.tunas[p.ID])
+			delete(w.tunas, p.ID)
+		}
+		if w.index != nil {
+			w.index.Remove(p.ID)
+		}
+		catches = append(catches, Catch{
+			PreyID:   p.ID,
+			HunterID: w.hunters[by].ID,
+			Time:     w.elapsed + at,
+			Position: p.Subject.Position.Add(preyVelocities[i].Scale(at)),
+		})
+	}
+	w.preys = left
+
+	// move every member
+	for i, h := range w.hunters {
+		w.move(h, hunterHeadings[i], hunterVelocities[i], dt)
+	}
+	for i, p := range w.preys {
+		w.move(p, leftVelocities[i], leftVelocities[i], dt)
+		w.indexPrey(p)
+	}
+	w.elapsed += dt
+	return
+}
+
+// move moves a member with its velocity during dt seconds, kept inside the arena
+func (w *World) move(m *Member, heading, velocity positioner.Position, dt float64) {
+	position := m.Subject.Position.Add(velocity.Scale(dt))
+	if w.arena != nil {
+		position, heading = w.arena.Constrain(position, heading)
+	}
+	*m.Subject.Position = position
+	m.Subject.Heading = &heading
+}
+
+// targets returns how to select the target of each hunter in this step
+// - with the index of the preys if the world has one and the selector can use it
+func (w *World) targets() (selectTarget func(h *Member) *Member) {
+	is, ok := w.selector.(IndexedTargetSelector)
+	if !ok || w.index == nil {
+		selectTarget = func(h *Member) *Member { return w.selector.Select(h, w.preys) }
+		return
+	}
+	byID := make(map[string]*Member, len(w.preys))
+	for _, p := range w.preys {
+		byID[p.ID] = p
+	}
+	selectTarget = func(h *Member) *Member { return is.SelectIndexed(h, w.index, byID) }
+	return
+}
+
+// indexPrey puts the current position of a prey in the index, if the world has one
+func (w *World) indexPrey(p *Member) {
+	if w.index == nil {
+		return
+	}
+	w.index.Move(p.ID, *p.Subject.Position)
+}
+
+// members returns all the members of the world
+func (w *World) members() (members []*Member) {
+	members = append(members, w.hunters...)
+	members = append(members, w.preys...)
+	return
+}
+
+// schoolHeadings returns the headings the schools give to their members, by member ID
+func (w *World) schoolHeadings() (headings map[string]positioner.Position) {
+	headings = make(map[string]positioner.Position)
+	if len(w.schools) == 0 {
+		return
+	}
+
+	hunters := subjects(w.hunters)
+	for id, school := range w.schools {
+		var members []*Member
+		for _, p := range w.preys {
+			if p.School == id {
+				members = append(members, p)
+			}
+		}
+		for i, heading := range school.Headings(subjects(members), hunters) {
+			if heading != nil {
+				headings[members[i].ID] = *heading
+			}
+		}
+	}
+	return
+}
+
+// subjects returns a copy of the subjects of the members
+func subjects(members []*Member) (ss []*simulator.Subject) {
+	ss = make([]*simulator.Subject, 0, len(members))
+	for _, m := range members {
+		s := m.Subject
+		ss = append(ss, &s)
+	}
+	return
+}
+
+// copyMembers returns a deep copy of the members
+func copyMembers(members []*Member) (copies []Member) {
+	copies = make([]Member, 0, len(members))
+	for _, m := range members {
+		c := *m
+		position := *m.Subject.Position
+		c.Subject.Position = &position
+		if m.Subject.Heading != nil {
+			heading := *m.Subject.Heading
+			c.Subject.Heading = &heading
+		}
+		copies = append(copies, c)
+	}
+	return
+}
+
+// nearest returns the nearest member to m (nil if there is none)
+func nearest(m *Member, others []*Member) (n *Member) {
+	best := math.Inf(1)
+	for _, o := range others {
+		if d := distance(m, o); d < best {
+			n, best = o, d
+		}
+	}
+	return
+}
+
+// distance returns the distance between 2 members
+func distance(a, b *Member) float64 {
+	return b.Subject.Position.Distance(*a.Subject.Position)
+}
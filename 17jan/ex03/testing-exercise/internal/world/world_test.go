@@ -0,0 +1,238 @@
+package world_test
+
+import (
+	"testdoubles/internal/hunter"
+	"testdoubles/internal/positioner"
+	"testdoubles/internal/prey"
+	"testdoubles/internal/species"
+	"testdoubles/internal/world"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Unit Tests for World
+func TestWorld_Add(t *testing.T) {
+	t.Run("success - members are added in order", func(t *testing.T) {
+		// arrange
+		impl := world.NewWorld(&world.ConfigWorld{MaxTime: 10})
+
+		// act
+		err1 := impl.AddHunter("shark", hunter.NewWhiteShark(hunter.ConfigWhiteShark{Speed: 10, Position: &positioner.Position{X: 1}}))
+		err2 := impl.AddPrey("tuna-1", prey.NewTuna(5, &positioner.Position{X: 2}))
+		err3 := impl.AddPrey("tuna-2", prey.NewTuna(6, &positioner.Position{X: 3}))
+
+		// assert
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		require.NoError(t, err3)
+		require.Len(t, impl.Hunters(), 1)
+		require.Equal(t, "shark", impl.Hunters()[0].ID)
+		require.Equal(t, 10.0, impl.Hunters()[0].Subject.Speed)
+		require.Len(t, impl.Preys(), 2)
+		require.Equal(t, "tuna-2", impl.Preys()[1].ID)
+		require.Equal(t, &positioner.Position{X: 3}, impl.Preys()[1].Subject.Position)
+	})
+
+	t.Run("failure - id is required", func(t *testing.T) {
+		// arrange
+		impl := world.NewWorld(&world.ConfigWorld{MaxTime: 10})
+
+		// act
+		err := impl.AddPrey("", prey.NewTuna(5, &positioner.Position{}))
+
+		// assert
+		require.ErrorIs(t, err, world.ErrMemberIDRequired)
+	})
+
+	t.Run("failure - id already exists", func(t *testing.T) {
+		// arrange
+		impl := world.NewWorld(&world.ConfigWorld{MaxTime: 10})
+		_ = impl.AddPrey("tuna", prey.NewTuna(5, &positioner.Position{}))
+
+		// act
+		err := impl.AddHunter("tuna", hunter.NewWhiteShark(hunter.ConfigWhiteShark{Speed: 10, Position: &positioner.Position{}}))
+
+		// assert
+		require.ErrorIs(t, err, world.ErrMemberIDDuplicated)
+		require.Len(t, impl.Hunters(), 0)
+	})
+
+	t.Run("failure - position is required", func(t *testing.T) {
+		// arrange
+		impl := world.NewWorld(&world.ConfigWorld{MaxTime: 10})
+
+		// act
+		err := impl.AddPrey("tuna", prey.NewTuna(5, nil))
+
+		// assert
+		require.ErrorIs(t, err, world.ErrMemberPositionRequired)
+	})
+}
+
+func TestWorld_Run(t *testing.T) {
+	t.Run("all the preys are caught one by one", func(t *testing.T) {
+		// arrange
+		impl := world.NewWorld(&world.ConfigWorld{MaxTime: 100, TimeStep: 0.5})
+		_ = impl.AddHunter("shark", hunter.NewWhiteShark(hunter.ConfigWhiteShark{Speed: 10, Position: &positioner.Position{X: 0}}))
+		_ = impl.AddPrey("tuna-1", prey.NewTuna(0, &positioner.Position{X: 11}))
+		_ = impl.AddPrey("tuna-2", prey.NewTuna(0, &positioner.Position{X: -20}))
+
+		// act
+		result := impl.Run()
+
+		// assert
+		require.True(t, result.AllCaught())
+		require.Len(t, result.Catches, 2)
+		require.Equal(t, world.Catch{PreyID: "tuna-1", HunterID: "shark", Time: 1, Position: positioner.Position{X: 11}}, result.Catches[0])
+		require.Equal(t, "tuna-2", result.Catches[1].PreyID)
+		require.InDelta(t, 1+(10+20-1)/10.0, result.Catches[1].Time, 1e-9)
+		require.Equal(t, result.Catches[1].Time, result.Duration)
+		require.Len(t, impl.Preys(), 0)
+	})
+
+	t.Run("each hunter of a pack catches its own prey", func(t *testing.T) {
+		// arrange
+		impl := world.NewWorld(&world.ConfigWorld{MaxTime: 100})
+		_ = impl.AddHunter("shark-1", hunter.NewWhiteShark(hunter.ConfigWhiteShark{Speed: 10, Position: &positioner.Position{X: 0}}))
+		_ = impl.AddHunter("shark-2", hunter.NewWhiteShark(hunter.ConfigWhiteShark{Speed: 10, Position: &positioner.Position{X: 200}}))
+		_ = impl.AddPrey("tuna-1", prey.NewTuna(5, &positioner.Position{X: 50}))
+		_ = impl.AddPrey("tuna-2", prey.NewTuna(5, &positioner.Position{X: 150}))
+
+		// act
+		result := impl.Run()
+
+		// assert
+		require.True(t, result.AllCaught())
+		require.Len(t, result.Catches, 2)
+		caughtBy := map[string]string{}
+		for _, c := range result.Catches {
+			caughtBy[c.PreyID] = c.HunterID
+		}
+		require.Equal(t, map[string]string{"tuna-1": "shark-1", "tuna-2": "shark-2"}, caughtBy)
+	})
+
+	t.Run("with a spatial index - same catches as scanning the preys", func(t *testing.T) {
+		// arrange
+		run := func(index positioner.SpatialIndex) *world.Result {
+			impl := world.NewWorld(&world.ConfigWorld{MaxTime: 100, TimeStep: 0.5, Index: index})
+			_ = impl.AddHunter("shark-1", hunter.NewWhiteShark(hunter.ConfigWhiteShark{Speed: 10, Position: &positioner.Position{X: 0}}))
+			_ = impl.AddHunter("shark-2", hunter.NewWhiteShark(hunter.ConfigWhiteShark{Speed: 12, Position: &positioner.Position{Y: 80}}))
+			_ = impl.AddPrey("tuna-1", prey.NewTuna(2, &positioner.Position{X: 30}))
+			_ = impl.AddPrey("tuna-2", prey.NewTuna(0, &positioner.Position{X: -40, Z: 10}))
+			_ = impl.AddPrey("tuna-3", prey.NewTuna(3, &positioner.Position{Y: 60, Z: -5}))
+			_ = impl.AddPrey("tuna-4", prey.NewTuna(1, &positioner.Position{X: 20, Y: 100}))
+			return impl.Run()
+		}
+
+		// act
+		expected := run(nil)
+		resultGrid := run(positioner.NewIndexGrid(10))
+		resultKDTree := run(positioner.NewIndexKDTree())
+
+		// assert
+		require.True(t, expected.AllCaught())
+		require.Equal(t, expected, resultGrid)
+		require.Equal(t, expected, resultKDTree)
+	})
+
+	t.Run("the time runs out - preys escape", func(t *testing.T) {
+		// arrange
+		impl := world.NewWorld(&world.ConfigWorld{MaxTime: 10})
+		_ = impl.AddHunter("shark", hunter.NewWhiteShark(hunter.ConfigWhiteShark{Speed: 5, Position: &positioner.Position{X: 0}}))
+		_ = impl.AddPrey("tuna", prey.NewTuna(10, &positioner.Position{X: 100}))
+
+		// act
+		result := impl.Run()
+
+		// assert
+		require.False(t, result.AllCaught())
+		require.Equal(t, []world.Catch{}, result.Catches)
+		require.Equal(t, []string{"tuna"}, result.Escaped)
+		require.InDelta(t, 10.0, result.Duration, 1e-9)
+		require.InDelta(t, 200.0, impl.Preys()[0].Subject.Position.X, 1e-9)
+		require.InDelta(t, 50.0, impl.Hunters()[0].Subject.Position.X, 1e-9)
+	})
+}
+
+func TestWorld_Run_CaptureRadius(t *testing.T) {
+	// arrange
+	// - a hunter of a species that catches from 5 meters, and a prey that does not move
+	profile := species.Profile{Name: "kraken", Speed: species.Distribution{Min: 1, Max: 1}, CaptureRadius: 5}
+	impl := world.NewWorld(&world.ConfigWorld{MaxTime: 20, TimeStep: 0.5})
+	_ = impl.AddHunter("kraken", hunter.NewSpecies(hunter.ConfigSpecies{Profile: profile, Position: &positioner.Position{}}))
+	_ = impl.AddPrey("tuna", prey.NewTuna(0, &positioner.Position{X: 10}))
+
+	// act
+	result := impl.Run()
+
+	// assert
+	require.True(t, result.AllCaught())
+	require.Equal(t, 5.0, impl.Hunters()[0].CaptureRadius)
+	require.InDelta(t, 5.0, result.Catches[0].Time, 1e-9)
+}
+
+func TestWorld_Run_School(t *testing.T) {
+	// arrange
+	// - school: 3 tunas slower than the shark
+	tunas := []*prey.Tuna{
+		prey.NewTunaWithConfig(prey.ConfigTuna{Speed: 3, Position: &positioner.Position{X: 50, Y: 0}}),
+		prey.NewTunaWithConfig(prey.ConfigTuna{Speed: 3, Position: &positioner.Position{X: 53, Y: 3}}),
+		prey.NewTunaWithConfig(prey.ConfigTuna{Speed: 3, Position: &positioner.Position{X: 53, Y: -3}}),
+	}
+	school := prey.NewSchool(prey.ConfigSchool{Members: tunas})
+	// - world: a shark that targets the school
+	impl := world.NewWorld(&world.ConfigWorld{MaxTime: 200, Selector: world.NewTargetSelectorSchool("school")})
+	_ = impl.AddHunter("shark", hunter.NewWhiteShark(hunter.ConfigWhiteShark{Speed: 10, Position: &positioner.Position{X: 0}}))
+	err := impl.AddSchool("school", school)
+
+	// act
+	result := impl.Run()
+
+	// assert
+	require.NoError(t, err)
+	require.True(t, result.AllCaught())
+	require.Len(t, result.Catches, 3)
+	caught := map[string]bool{}
+	for i, c := range result.Catches {
+		caught[c.PreyID] = true
+		if i > 0 {
+			require.Greater(t, c.Time, result.Catches[i-1].Time)
+		}
+	}
+	require.Equal(t, map[string]bool{"school-1": true, "school-2": true, "school-3": true}, caught)
+	require.Len(t, school.Members(), 0)
+}
+
+func TestWorld_AddSchool(t *testing.T) {
+	t.Run("members are added as preys of the school", func(t *testing.T) {
+		// arrange
+		school := prey.NewSchool(prey.ConfigSchool{Members: []*prey.Tuna{
+			prey.NewTunaWithConfig(prey.ConfigTuna{Speed: 3, Position: &positioner.Position{X: 1}}),
+			prey.NewTunaWithConfig(prey.ConfigTuna{Speed: 4, Position: &positioner.Position{X: 2}}),
+		}})
+		impl := world.NewWorld(&world.ConfigWorld{MaxTime: 10})
+
+		// act
+		err := impl.AddSchool("school", school)
+
+		// assert
+		require.NoError(t, err)
+		require.Len(t, impl.Preys(), 2)
+		require.Equal(t, "school-2", impl.Preys()[1].ID)
+		require.Equal(t, "school", impl.Preys()[1].School)
+		require.Equal(t, 4.0, impl.Preys()[1].Subject.Speed)
+	})
+
+	t.Run("failure - id already exists", func(t *testing.T) {
+		// arrange
+		impl := world.NewWorld(&world.ConfigWorld{MaxTime: 10})
+		_ = impl.AddPrey("school", prey.NewTuna(5, &positioner.Position{}))
+
+		// act
+		err := impl.AddSchool("school", prey.NewSchool(prey.ConfigSchool{}))
+
+		// assert
+		require.ErrorIs(t, err, world.ErrMemberIDDuplicated)
+	})
+}